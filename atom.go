@@ -8,7 +8,7 @@ var (
 
 func init() {
 	atoms = map[string]func(any) bool{
-		"phone":               IsNumeric,
+		"phone":               IsPhone,
 		"username":            IsUsername,
 		"password":            IsPassword,
 		"json":                IsJSON,
@@ -17,6 +17,8 @@ func init() {
 		"countryCodeAlpha3":   IsCountryCodeAlpha3,
 		"countryCodeNumeric":  IsCountryCodeAlphaNumeric,
 		"countryCode":         IsCountryCode,
+		"subdivision":         IsSubdivision,
+		"languageTag":         IsLanguageTag,
 		"currency":            IsCurrencyCode,
 		"currencyNumeric":     IsCurrencyCodeNumeric,
 		"alpha":               IsAlpha,
@@ -82,6 +84,15 @@ func init() {
 		"cve":                 IsCve,
 		"mongodb":             IsMongodb,
 		"cron":                IsCron,
+		"luhn":                IsLuhn,
+		"creditCard":          IsCreditCard,
+		"iban":                IsIBAN,
+		"isbn":                IsISBN,
+		"ean8":                IsEAN8,
+		"ean13":               IsEAN13,
+		"upca":                IsUPCA,
+		"isin":                IsISIN,
+		"vat":                 IsVAT,
 	}
 }
 
@@ -0,0 +1,194 @@
+package validate
+
+import (
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dev-mockingbird/logf"
+)
+
+// tagName names the field by the tag, the json tag or else its go name, "-" skips the field
+func tagName(f reflect.StructField, tag string) string {
+	for _, t := range []string{tag, "json"} {
+		if t == "" {
+			continue
+		}
+		if name := strings.Split(f.Tag.Get(t), ",")[0]; name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// fieldByTagName finds the exported field of the struct type named as tagName says
+func fieldByTagName(typ reflect.Type, name, tag string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.IsExported() && tagName(f, tag) == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// bindValues fills the fields of the struct from values like url.Values, a field is named as tagName says.
+// a slice takes every value of its name, a value that can't be parsed gives a CodeType error
+func (v *validator) bindValues(dst reflect.Value, values map[string][]string, tag string) (errs ValidateErrors) {
+	for dst.Kind() == reflect.Ptr {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+	if dst.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < dst.NumField(); i++ {
+		f := dst.Type().Field(i)
+		name := tagName(f, tag)
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if tag == "header" {
+			name = textproto.CanonicalMIMEHeaderKey(name)
+		}
+		vals := values[name]
+		if len(vals) == 0 {
+			continue
+		}
+		if err := setValues(dst.Field(i), vals); err != nil {
+			errs = append(errs, v.typeError(Path{}.field(f, v.fieldName(f)), f.Type))
+		}
+	}
+	return
+}
+
+func setValues(val reflect.Value, vals []string) error {
+	if val.Kind() != reflect.Slice || len(vals) == 1 {
+		return setValue(val, vals[0])
+	}
+	s := reflect.MakeSlice(val.Type(), len(vals), len(vals))
+	for i, raw := range vals {
+		if err := setValue(s.Index(i), raw); err != nil {
+			return err
+		}
+	}
+	val.Set(s)
+	return nil
+}
+
+// typeError reports a value that can't be turned into the type of the field
+func (v *validator) typeError(p Path, typ reflect.Type) ValidateError {
+	kind := typ.String()
+	return ValidateError{
+		Fields:  []string{p.Format(v.pathFormat)},
+		Paths:   []Path{p},
+		Code:    CodeType,
+		Params:  kind,
+		Message: v.printer.Sprintf("should be of type [%s]", kind),
+	}
+}
+
+// coerceValues validates the values their rules say are numbers as numbers, taking them out of data, see ValidateValues
+func (v *validator) coerceValues(data map[string]string) (numbers, errs ValidateErrors) {
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := Path{}.key(name)
+		rule := v.getRule(p, "")
+		typ := valueType(rule)
+		if typ == nil || data[name] == "" {
+			continue
+		}
+		val := reflect.New(typ).Elem()
+		if err := setValue(val, data[name]); err != nil {
+			errs = append(errs, v.typeError(p, typ))
+			continue
+		}
+		delete(data, name)
+		rule.path = p
+		_, err := rule.Validate(val, p.String())
+		numbers = append(numbers, err...)
+	}
+	return
+}
+
+// valueType is the type a value of the Rules form of ValidateValues is taken as, nil for a string
+func valueType(rule Rule) reflect.Type {
+	for _, a := range rule.IsA {
+		switch a {
+		case "number":
+			return reflect.TypeOf(uint(0))
+		case "numeric":
+			return reflect.TypeOf(float64(0))
+		}
+	}
+	return nil
+}
+
+// ValidateQuery validates url query values, see ValidateValues
+func ValidateQuery(values url.Values, schema any, opt ...Option) ValidateErrors {
+	return ValidateValues(values, "query", schema, opt...)
+}
+
+// ValidateForm validates form values, see ValidateValues
+func ValidateForm(values url.Values, schema any, opt ...Option) ValidateErrors {
+	return ValidateValues(values, "form", schema, opt...)
+}
+
+// ValidateHeader validates request headers, see ValidateValues. names from tags and Rules are canonicalized like http.Header does
+func ValidateHeader(header http.Header, schema any, opt ...Option) ValidateErrors {
+	return ValidateValues(header, "header", schema, opt...)
+}
+
+// ValidateValues validates values by a schema, which is a pointer to a struct or Rules.
+// a struct is filled from the values, a field named by the tag, the json tag or its go name, before it's validated.
+// with Rules, the first value of each name is validated as a string and names the rules have are never missing,
+// but a value is an uint when its rule has `is:number` and a float64 with `is:numeric`, so `min` and `max` bound the number.
+// a value that can't be parsed gives a CodeType error. fields are named by the tag in errors unless opt says otherwise
+func ValidateValues(values map[string][]string, tag string, schema any, opt ...Option) ValidateErrors {
+	v := validator{}.Config(append([]Option{NameTag(tag)}, opt...)...)
+	canonical := func(name string) string { return name }
+	if tag == "header" {
+		canonical = textproto.CanonicalMIMEHeaderKey
+	}
+	if rules, ok := schema.(Rules); ok {
+		data := make(map[string]string)
+		canonicalRules := make(Rules)
+		for name, rule := range rules {
+			p := ParsePath(name)
+			if len(p) > 0 {
+				p[0].Name = canonical(p[0].Name)
+				data[p[0].Name] = ""
+			}
+			canonicalRules[p.String()] = rule
+		}
+		for name, vals := range values {
+			if len(vals) > 0 {
+				data[canonical(name)] = vals[0]
+			}
+		}
+		v = v.With(canonicalRules)
+		numbers, errs := v.coerceValues(data)
+		if len(errs) > 0 {
+			return errs
+		}
+		return append(v.Validate(data), numbers...)
+	}
+	dst := reflect.ValueOf(schema)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		v.logger.Logf(logf.Error, "schema should be a pointer to a struct or Rules, got %T", schema)
+		return nil
+	}
+	if errs := v.bindValues(dst, values, tag); len(errs) > 0 {
+		return errs
+	}
+	return v.Validate(schema)
+}
@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/tj/assert"
+)
+
+type ListQuery struct {
+	Page    int           `query:"page" form:"p" validate:"min:1"`
+	Size    uint8         `query:"size" validate:"max:100"`
+	Sort    []string      `query:"sort" validate:"omitempty"`
+	Timeout time.Duration `json:"timeout" validate:"omitempty"`
+}
+
+type AuthHeader struct {
+	RequestID string `header:"X-Request-ID" validate:"min:8"`
+	Token     string `header:"authorization"`
+}
+
+func TestValidateQuery(t *testing.T) {
+	var q ListQuery
+	err := ValidateQuery(url.Values{"page": {"2"}, "size": {"20"}, "sort": {"name", "-age"}, "timeout": {"3s"}}, &q)
+	assert.Nil(t, err)
+	assert.Equal(t, ListQuery{Page: 2, Size: 20, Sort: []string{"name", "-age"}, Timeout: 3 * time.Second}, q)
+
+	q = ListQuery{}
+	err = ValidateQuery(url.Values{"page": {"0"}, "size": {"20"}}, &q)
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, []string{".page"}, err[0].Fields)
+	assert.Equal(t, "Page", err[0].Paths[0][0].GoName)
+
+	q = ListQuery{}
+	err = ValidateQuery(url.Values{"page": {"one"}, "size": {"300"}}, &q, FieldPaths(JSONPointer))
+	assert.True(t, len(err) == 2)
+	assert.Equal(t, []string{"/page"}, err[0].Fields)
+	assert.Equal(t, CodeType, err[0].Code)
+	assert.Equal(t, "should be of type [int]", err[0].Message)
+	assert.Equal(t, []string{"/size"}, err[1].Fields)
+	assert.Equal(t, "should be of type [uint8]", err[1].Message)
+
+	err = ValidateQuery(url.Values{"size": {"x"}}, &ListQuery{}, NameTag(""))
+	assert.Equal(t, []string{".Size"}, err[0].Fields)
+
+	err = ValidateForm(url.Values{"p": {"0"}, "size": {"1"}}, &ListQuery{})
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, []string{".p"}, err[0].Fields)
+}
+
+func TestValidateQuery_rules(t *testing.T) {
+	rules := Rules{"page": "min:1;max:3", "/q": "omitempty", ".sort": "enum:asc,desc"}
+	err := ValidateQuery(url.Values{"page": {"12"}, "sort": {"asc"}}, rules)
+	assert.Nil(t, err)
+	err = ValidateQuery(url.Values{"page": {"1234"}, "q": {"x"}}, rules)
+	assert.True(t, len(err) == 2)
+	byField := err.ByField()
+	assert.Equal(t, CodeMax, byField[".page"][0].Code)
+	assert.Equal(t, CodeRequired, byField[".sort"][0].Code)
+
+	rules = Rules{"page": "is:number;min:1;max:100", "lat": "is:numeric;min:-90;max:90"}
+	assert.Nil(t, ValidateQuery(url.Values{"page": {"12"}, "lat": {"-45.5"}}, rules))
+	err = ValidateQuery(url.Values{"page": {"0"}, "lat": {"90.5"}}, rules)
+	assert.Equal(t, 2, len(err), err)
+	assert.Equal(t, CodeMin, err.For(".page")[0].Code)
+	assert.Equal(t, CodeMax, err.For(".lat")[0].Code)
+	err = ValidateQuery(url.Values{"page": {"abc"}, "lat": {"north"}}, rules)
+	assert.Equal(t, 2, len(err), err)
+	assert.Equal(t, CodeType, err[0].Code)
+	assert.Equal(t, "should be of type [float64]", err[0].Message)
+	assert.Equal(t, []string{".page"}, err[1].Fields)
+	assert.Equal(t, "should be of type [uint]", err[1].Message)
+	assert.True(t, ValidateQuery(url.Values{}, rules).For(".page").Has(CodeRequired))
+}
+
+func TestValidateHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-Request-Id", "abcdefgh")
+	h.Set("Authorization", "Bearer x")
+	var a AuthHeader
+	assert.Nil(t, ValidateHeader(h, &a))
+	assert.Equal(t, AuthHeader{RequestID: "abcdefgh", Token: "Bearer x"}, a)
+
+	h.Set("X-Request-Id", "abc")
+	err := ValidateHeader(h, &AuthHeader{})
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, []string{".X-Request-Id"}, err[0].Fields)
+	assert.Equal(t, err[0].Fields, ValidateHeader(h, Rules{".X-Request-ID": "min:8"})[0].Fields)
+
+	err = ValidateHeader(h, Rules{".x-request-id": "min:8", ".X-Trace": ""})
+	assert.True(t, len(err) == 2)
+	byField := err.ByField()
+	assert.Equal(t, CodeMin, byField[".X-Request-Id"][0].Code)
+	assert.Equal(t, CodeRequired, byField[".X-Trace"][0].Code)
+}
+
+func TestNameTag(t *testing.T) {
+	err := Get(NameTag("json")).Validate(SignUp{Age: 20})
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, []string{".email"}, err[0].Fields)
+	err = Get(NameTag("json"), NameCase(SnakeCase)).Validate(ListQuery{Size: 1})
+	assert.Equal(t, []string{".page"}, err[0].Fields)
+	err = Get(NameTag("query"), NameCase(KebabCase)).Validate(ListQuery{Page: 1, Size: 200})
+	assert.Equal(t, []string{".size"}, err[0].Fields)
+}
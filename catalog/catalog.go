@@ -1,79 +1,209 @@
-// Code generated by running "go generate" in golang.org/x/text. DO NOT EDIT.
-
-package validate
+// Package catalog bundles the translations of the messages emitted by validate.
+// translations are kept as gotext json files, more of them can be merged in at runtime with Load
+package catalog
 
 import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
 	"golang.org/x/text/language"
-	"golang.org/x/text/message"
+	printer "golang.org/x/text/message"
 	"golang.org/x/text/message/catalog"
 )
 
-type dictionary struct {
-	index []uint32
-	data  string
+//go:embed locales/*/out.gotext.json
+var locales embed.FS
+
+// Default holds the bundled translations, english is the fallback language.
+// they are set to the DefaultCatalog of x/text/message as well, so a printer of the default catalog translates them too
+var Default catalog.Catalog
+
+func init() {
+	b, err := New()
+	if err != nil {
+		panic(err)
+	}
+	Default = b
+	if err := load(printer.Set, locales, "locales/*/out.gotext.json"); err != nil {
+		panic(err)
+	}
+}
+
+// New creates a builder filled with the bundled translations
+func New(opts ...catalog.Option) (*catalog.Builder, error) {
+	b := catalog.NewBuilder(append([]catalog.Option{catalog.Fallback(language.English)}, opts...)...)
+	return b, Load(b, locales, "locales/*/out.gotext.json")
+}
+
+type placeholder struct {
+	ID     string `json:"id"`
+	String string `json:"string"`
+	ArgNum int    `json:"argNum"`
 }
 
-func (d *dictionary) Lookup(key string) (data string, ok bool) {
-	p, ok := messageKeyToIndex[key]
-	if !ok {
-		return "", false
+// text is a translation, either a plain string or a plural select like
+// {"select": {"feature": "plural", "arg": "Min", "cases": {"one": {"msg": "..."}, "other": {"msg": "..."}}}}
+type text struct {
+	Msg    string    `json:"msg"`
+	Select *selector `json:"select"`
+}
+
+type selector struct {
+	Feature string          `json:"feature"`
+	Arg     string          `json:"arg"`
+	Cases   map[string]text `json:"cases"`
+}
+
+func (t *text) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.Msg); err == nil {
+		return nil
 	}
-	start, end := d.index[p], d.index[p+1]
-	if start == end {
-		return "", false
+	type raw text
+	return json.Unmarshal(data, (*raw)(t))
+}
+
+type message struct {
+	Message      string        `json:"message"`
+	Translation  text          `json:"translation"`
+	Placeholders []placeholder `json:"placeholders"`
+}
+
+type messages struct {
+	Language string    `json:"language"`
+	Messages []message `json:"messages"`
+}
+
+var argIndex = regexp.MustCompile(`^%(\[\d+\])?`)
+
+// Load merges the gotext json files matching the patterns into b, a message already in b is replaced.
+// the key of a message is its source text with the placeholders turned back into verbs, e.g. "has a minimum length [%d]",
+// so a literal % is doubled in it, but kept as it is in the key of a message without placeholders like a label.
+// a literal % of a translation is never a verb
+func Load(b *catalog.Builder, fsys fs.FS, patterns ...string) error {
+	return load(b.Set, fsys, patterns...)
+}
+
+// setter sets the messages of a key in a language, like catalog.Builder.Set and printer.Set
+type setter func(tag language.Tag, key string, msg ...catalog.Message) error
+
+func load(set setter, fsys fs.FS, patterns ...string) error {
+	for _, pattern := range patterns {
+		files, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			data, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return err
+			}
+			if err := loadMessages(set, data); err != nil {
+				return fmt.Errorf("load %s: %w", file, err)
+			}
+		}
 	}
-	return d.data[start:end], true
+	return nil
 }
 
-func init() {
-	dict := map[string]catalog.Dictionary{
-		"en": &dictionary{index: enIndex, data: enData},
-		"zh": &dictionary{index: zhIndex, data: zhData},
+func loadMessages(set setter, data []byte) error {
+	var msgs messages
+	if err := json.Unmarshal(data, &msgs); err != nil {
+		return err
 	}
-	fallback := language.MustParse("en")
-	cat, err := catalog.NewFromMap(dict, catalog.Fallback(fallback))
+	tag, err := language.Parse(msgs.Language)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	for _, m := range msgs.Messages {
+		if m.Translation.Msg == "" && m.Translation.Select == nil {
+			continue
+		}
+		// a message without placeholders is looked up by its text, one with them by the format they make
+		key, source := make([]string, 0, 2*len(m.Placeholders)), m.Message
+		for _, p := range m.Placeholders {
+			key = append(key, "{"+p.ID+"}", "%"+argIndex.ReplaceAllString(p.String, ""))
+		}
+		if len(m.Placeholders) > 0 {
+			source = strings.ReplaceAll(source, "%", "%%")
+		}
+		msg, err := m.compile(m.Translation)
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Message, err)
+		}
+		if err := set(tag, strings.NewReplacer(key...).Replace(source), msg...); err != nil {
+			return err
+		}
 	}
-	message.DefaultCatalog = cat
+	return nil
 }
 
-var messageKeyToIndex = map[string]int{
-	"at least one of [%s] should be valued": 9,
-	"cound be malformed":                    2,
-	"has a maximum length [%d]":             5,
-	"has a minimum length [%d]":             4,
-	"is not a %s":                           1,
-	"not allow empty":                       0,
-	"should be greater than equal [%d], current value is [%d]": 7,
-	"should be less than equal [%d], current value is [%d]":    8,
-	"should be one of [%s], current value is [%d]":             6,
-	"should be one of [%s], current value is [%s]":             3,
+// compile turns a translation into catalog messages, the first one that can be rendered is used
+func (m message) compile(t text) ([]catalog.Message, error) {
+	var msgs []catalog.Message
+	if t.Select != nil {
+		msg, err := m.compileSelect(t.Select)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	if t.Msg != "" {
+		msgs = append(msgs, catalog.String(m.substitute(t.Msg)))
+	}
+	if len(msgs) == 0 {
+		return nil, errors.New("empty message")
+	}
+	return msgs, nil
+}
+
+func (m message) compileSelect(s *selector) (catalog.Message, error) {
+	if s.Feature != "plural" {
+		return nil, fmt.Errorf("unknown feature type %q", s.Feature)
+	}
+	var ph *placeholder
+	for i := range m.Placeholders {
+		if m.Placeholders[i].ID == s.Arg {
+			ph = &m.Placeholders[i]
+		}
+	}
+	if ph == nil {
+		return nil, fmt.Errorf("unknown placeholder %q", s.Arg)
+	}
+	keys := make([]string, 0, len(s.Cases))
+	for k := range s.Cases {
+		keys = append(keys, k)
+	}
+	// "other" matches anything so it goes last, "=1" goes before "one" as '=' sorts before letters
+	sort.Slice(keys, func(i, j int) bool {
+		if (keys[i] == "other") != (keys[j] == "other") {
+			return keys[j] == "other"
+		}
+		return keys[i] < keys[j]
+	})
+	cases := make([]interface{}, 0, 2*len(keys))
+	for _, k := range keys {
+		msgs, err := m.compile(s.Cases[k])
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, k, msgs[0])
+	}
+	return plural.Selectf(ph.ArgNum, ph.String, cases...), nil
 }
 
-var enIndex = []uint32{ // 11 elements
-	0x00000000, 0x00000010, 0x0000001f, 0x00000032,
-	0x00000065, 0x00000082, 0x0000009f, 0x000000d2,
-	0x00000111, 0x0000014d, 0x00000176,
-} // Size: 68 bytes
-
-const enData string = "" + // Size: 374 bytes
-	"\x02not allow empty\x02is not a %[1]s\x02cound be malformed\x02should be" +
-	" one of [%[1]s], current value is [%[2]s]\x02has a minimum length [%[1]d" +
-	"]\x02has a maximum length [%[1]d]\x02should be one of [%[1]s], current v" +
-	"alue is [%[2]d]\x02should be greater than equal [%[1]d], current value i" +
-	"s [%[2]d]\x02should be less than equal [%[1]d], current value is [%[2]d]" +
-	"\x02at least one of [%[1]s] should be valued"
-
-var zhIndex = []uint32{ // 11 elements
-	0x00000000, 0x00000010, 0x0000001c, 0x00000029,
-	0x0000005d, 0x00000083, 0x000000a9, 0x000000dd,
-	0x0000010d, 0x0000013d, 0x00000160,
-} // Size: 68 bytes
-
-const zhData string = "" + // Size: 352 bytes
-	"\x02不允许为空\x02不是%[1]s\x02格式错误\x02应该是[%[1]s]中的一个，当前值为 [%[2]s]\x02不满足最小长度[%" +
-	"[1]d]的约束\x02不满足最大长度[%[1]d]的约束\x02应该是[%[1]s]中的一个，当前值为 [%[2]d]\x02应该大于等于[%" +
-	"[1]d]，当前值为[%[2]d]\x02应该小于等于[%[1]d]，当前值为[%[2]d]\x02至少[%[1]s]中的一个被赋值"
-
-	// Total table size 862 bytes (0KiB); checksum: 63589230
+// substitute turns the placeholders of a translation into the verbs of the message, a literal % is doubled
+func (m message) substitute(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	r := make([]string, 0, 2*len(m.Placeholders))
+	for _, p := range m.Placeholders {
+		r = append(r, "{"+p.ID+"}", p.String)
+	}
+	return strings.NewReplacer(r...).Replace(s)
+}
@@ -0,0 +1,478 @@
+package validate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// iban lengths per country, see: https://www.swift.com/standards/data-standards/iban
+var ibanLength = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22,
+	"CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20,
+	"EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22,
+	"GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28,
+	"IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30,
+	"KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21,
+	"LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+	"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27,
+	"SO": 23, "ST": 25, "SV": 28, "TL": 23, "TN": 24, "TR": 26, "UA": 29,
+	"VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+type vatRule struct {
+	format   *regexp.Regexp
+	checksum func(string) bool
+}
+
+// eu vat numbers, keyed by the prefix used by VIES (greece uses EL)
+var vatRules = map[string]vatRule{
+	"AT": {regexp.MustCompile(`^U\d{8}$`), vatChecksumAT},
+	"BE": {regexp.MustCompile(`^[01]\d{9}$`), vatChecksumBE},
+	"BG": {regexp.MustCompile(`^\d{9,10}$`), nil},
+	"CY": {regexp.MustCompile(`^\d{8}[A-Z]$`), nil},
+	"CZ": {regexp.MustCompile(`^\d{8,10}$`), nil},
+	"DE": {regexp.MustCompile(`^\d{9}$`), vatChecksumDE},
+	"DK": {regexp.MustCompile(`^\d{8}$`), vatChecksumDK},
+	"EE": {regexp.MustCompile(`^\d{9}$`), nil},
+	"EL": {regexp.MustCompile(`^\d{9}$`), nil},
+	"ES": {regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`), nil},
+	"FI": {regexp.MustCompile(`^\d{8}$`), vatChecksumFI},
+	"FR": {regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`), vatChecksumFR},
+	"HR": {regexp.MustCompile(`^\d{11}$`), vatChecksumHR},
+	"HU": {regexp.MustCompile(`^\d{8}$`), nil},
+	"IE": {regexp.MustCompile(`^(?:\d{7}[A-W][A-IW]?|\d[A-Z+*]\d{5}[A-W])$`), nil},
+	"IT": {regexp.MustCompile(`^\d{11}$`), vatChecksumIT},
+	"LT": {regexp.MustCompile(`^(?:\d{9}|\d{12})$`), nil},
+	"LU": {regexp.MustCompile(`^\d{8}$`), vatChecksumLU},
+	"LV": {regexp.MustCompile(`^\d{11}$`), nil},
+	"MT": {regexp.MustCompile(`^\d{8}$`), nil},
+	"NL": {regexp.MustCompile(`^\d{9}B\d{2}$`), vatChecksumNL},
+	"PL": {regexp.MustCompile(`^\d{10}$`), vatChecksumPL},
+	"PT": {regexp.MustCompile(`^\d{9}$`), vatChecksumPT},
+	"RO": {regexp.MustCompile(`^[1-9]\d{1,9}$`), nil},
+	"SE": {regexp.MustCompile(`^\d{10}01$`), vatChecksumSE},
+	"SI": {regexp.MustCompile(`^\d{8}$`), nil},
+	"SK": {regexp.MustCompile(`^\d{10}$`), vatChecksumSK},
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+func digitsOf(s string) ([]int, bool) {
+	ds := make([]int, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return nil, false
+		}
+		ds[i] = int(s[i] - '0')
+	}
+	return ds, true
+}
+
+func stripSeparators(s string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(s)
+}
+
+func luhn(s string) bool {
+	ds, ok := digitsOf(s)
+	if !ok || len(ds) < 2 {
+		return false
+	}
+	sum := 0
+	for i := len(ds) - 1; i >= 0; i-- {
+		d := ds[i]
+		if (len(ds)-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// gtin covers EAN-8, UPC-A, EAN-13 and GTIN-14, which share the same mod 10 check digit
+func gtin(s string, length int) bool {
+	ds, ok := digitsOf(s)
+	if !ok || len(ds) != length {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(ds)-1; i++ {
+		if (len(ds)-1-i)%2 == 1 {
+			sum += ds[i] * 3
+		} else {
+			sum += ds[i]
+		}
+	}
+	return (10-sum%10)%10 == ds[len(ds)-1]
+}
+
+// mod97 computes the remainder of an alphanumeric string, letters being expanded to 10..35
+func mod97(s string) (int, bool) {
+	rem := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case '0' <= c && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case 'A' <= c && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		default:
+			return 0, false
+		}
+	}
+	return rem, true
+}
+
+func isbn10Checksum(s string) bool {
+	sum := 0
+	for i := 0; i < 10; i++ {
+		d := int(s[i] - '0')
+		if s[i] == 'X' {
+			d = 10
+		}
+		sum += d * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+func IsLuhn(val any) bool {
+	s, ok := val.(string)
+	if !ok {
+		return false
+	}
+	return luhn(s)
+}
+
+func IsCreditCard(val any) bool {
+	s, ok := val.(string)
+	if !ok {
+		return false
+	}
+	s = stripSeparators(s)
+	if len(s) < 12 || len(s) > 19 {
+		return false
+	}
+	return luhn(s)
+}
+
+func IsIBAN(val any) bool {
+	s, ok := val.(string)
+	if !ok {
+		return false
+	}
+	s = strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if len(s) < 4 || ibanLength[s[:2]] != len(s) {
+		return false
+	}
+	rem, ok := mod97(s[4:] + s[:4])
+	return ok && rem == 1
+}
+
+func IsISBN(val any) bool {
+	return IsISBN10(val) || IsISBN13(val)
+}
+
+func IsEAN8(val any) bool {
+	s, ok := val.(string)
+	return ok && gtin(s, 8)
+}
+
+func IsEAN13(val any) bool {
+	s, ok := val.(string)
+	return ok && gtin(s, 13)
+}
+
+func IsUPCA(val any) bool {
+	s, ok := val.(string)
+	return ok && gtin(s, 12)
+}
+
+func IsISIN(val any) bool {
+	s, ok := val.(string)
+	if !ok || len(s) != 12 || !iso3166_1_alpha2[s[:2]] && s[:2] != "XS" && s[:2] != "EU" {
+		return false
+	}
+	var expanded strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case '0' <= c && c <= '9':
+			expanded.WriteByte(c)
+		case 'A' <= c && c <= 'Z' && i < 11:
+			expanded.WriteString(twoDigits(int(c-'A') + 10))
+		default:
+			return false
+		}
+	}
+	return luhn(expanded.String())
+}
+
+func twoDigits(n int) string {
+	return string([]byte{byte('0' + n/10), byte('0' + n%10)})
+}
+
+// IsVAT checks an EU VAT identification number, prefixed with its country code.
+// Countries without a public check digit algorithm are only checked against their format.
+func IsVAT(val any) bool {
+	s, ok := val.(string)
+	if !ok {
+		return false
+	}
+	s = strings.ToUpper(strings.NewReplacer(" ", "", "-", "", ".", "").Replace(s))
+	if len(s) < 4 {
+		return false
+	}
+	rule, ok := vatRules[s[:2]]
+	if !ok || !rule.format.MatchString(s[2:]) {
+		return false
+	}
+	return rule.checksum == nil || rule.checksum(s[2:])
+}
+
+func vatChecksumAT(s string) bool {
+	ds, _ := digitsOf(s[1:])
+	sum := 0
+	for i := 0; i < 7; i++ {
+		d := ds[i]
+		if i%2 == 1 {
+			d *= 2
+			d = d/10 + d%10
+		}
+		sum += d
+	}
+	return (10-(sum+4)%10)%10 == ds[7]
+}
+
+func vatChecksumBE(s string) bool {
+	n, _ := strconv.ParseInt(s[:8], 10, 64)
+	return 97-n%97 == int64((s[8]-'0')*10+s[9]-'0')
+}
+
+func iso7064Mod1110(ds []int) bool {
+	product := 10
+	for _, d := range ds[:len(ds)-1] {
+		sum := (d + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (2 * sum) % 11
+	}
+	return (11-product)%10 == ds[len(ds)-1]
+}
+
+func vatChecksumDE(s string) bool {
+	ds, _ := digitsOf(s)
+	return iso7064Mod1110(ds)
+}
+
+func vatChecksumHR(s string) bool {
+	ds, _ := digitsOf(s)
+	return iso7064Mod1110(ds)
+}
+
+func weightedSum(ds []int, weights ...int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += ds[i] * w
+	}
+	return sum
+}
+
+func vatChecksumDK(s string) bool {
+	ds, _ := digitsOf(s)
+	return weightedSum(ds, 2, 7, 6, 5, 4, 3, 2, 1)%11 == 0
+}
+
+func vatChecksumFI(s string) bool {
+	ds, _ := digitsOf(s)
+	check := 11 - weightedSum(ds, 7, 9, 10, 5, 8, 4, 2)%11
+	if check == 11 {
+		check = 0
+	}
+	return check == ds[7]
+}
+
+func vatChecksumFR(s string) bool {
+	if _, ok := digitsOf(s[:2]); !ok {
+		// the new style alphanumeric keys can't be verified offline
+		return true
+	}
+	siren, _ := strconv.ParseInt(s[2:], 10, 64)
+	key := (12 + 3*(siren%97)) % 97
+	return key == int64((s[0]-'0')*10+s[1]-'0')
+}
+
+func vatChecksumIT(s string) bool {
+	return luhn(s)
+}
+
+func vatChecksumLU(s string) bool {
+	ds, _ := digitsOf(s)
+	n := 0
+	for _, d := range ds[:6] {
+		n = n*10 + d
+	}
+	return n%89 == ds[6]*10+ds[7]
+}
+
+func vatChecksumNL(s string) bool {
+	ds, _ := digitsOf(s[:9])
+	if weightedSum(ds, 9, 8, 7, 6, 5, 4, 3, 2)%11 == ds[8] {
+		return true
+	}
+	// sole proprietors get a number verified with mod 97 over the whole identifier
+	rem, ok := mod97("NL" + s)
+	return ok && rem == 1
+}
+
+func vatChecksumPL(s string) bool {
+	ds, _ := digitsOf(s)
+	return weightedSum(ds, 6, 5, 7, 2, 3, 4, 5, 6, 7)%11 == ds[9]
+}
+
+func vatChecksumPT(s string) bool {
+	ds, _ := digitsOf(s)
+	check := 11 - weightedSum(ds, 9, 8, 7, 6, 5, 4, 3, 2)%11
+	if check >= 10 {
+		check = 0
+	}
+	return check == ds[8]
+}
+
+func vatChecksumSE(s string) bool {
+	return luhn(s[:10])
+}
+
+func vatChecksumSK(s string) bool {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n%11 == 0
+}
+
+func base58Decode(s string) ([]byte, bool) {
+	n := big.NewInt(0)
+	radix := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		idx := strings.IndexByte(base58Alphabet, s[i])
+		if idx < 0 {
+			return nil, false
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+	decoded := n.Bytes()
+	for i := 0; i < len(s) && s[i] == base58Alphabet[0]; i++ {
+		decoded = append([]byte{0}, decoded...)
+	}
+	return decoded, true
+}
+
+func base58Check(s string) bool {
+	decoded, ok := base58Decode(s)
+	if !ok || len(decoded) != 25 {
+		return false
+	}
+	first := sha256.Sum256(decoded[:21])
+	second := sha256.Sum256(first[:])
+	return bytes.Equal(second[:4], decoded[21:])
+}
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// segwitChecksum verifies a bech32 (BIP-173) or bech32m (BIP-350) segwit address
+func segwitChecksum(s string) bool {
+	s = strings.ToLower(s)
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || len(s)-pos-1 < 6 {
+		return false
+	}
+	hrp, data := s[:pos], s[pos+1:]
+	values := make([]byte, 0, len(hrp)*2+1+len(data))
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	words := make([]byte, len(data))
+	for i := 0; i < len(data); i++ {
+		idx := strings.IndexByte(bech32Charset, data[i])
+		if idx < 0 {
+			return false
+		}
+		words[i] = byte(idx)
+	}
+	values = append(values, words...)
+	witnessVersion := words[0]
+	if witnessVersion > 16 {
+		return false
+	}
+	expected := uint32(bech32mConst)
+	if witnessVersion == 0 {
+		expected = bech32Const
+	}
+	if bech32Polymod(values) != expected {
+		return false
+	}
+	program := len(words) - 7
+	programBytes := program * 5 / 8
+	if programBytes < 2 || programBytes > 40 {
+		return false
+	}
+	return witnessVersion != 0 || programBytes == 20 || programBytes == 32
+}
+
+// eip55Checksum verifies the mixed case checksum of an ethereum address,
+// addresses in a single case carry no checksum and are accepted as is
+func eip55Checksum(s string) bool {
+	addr := s[2:]
+	if addr == strings.ToLower(addr) || addr == strings.ToUpper(addr) {
+		return true
+	}
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(strings.ToLower(addr)))
+	digest := hex.EncodeToString(hash.Sum(nil))
+	for i := 0; i < len(addr); i++ {
+		c := addr[i]
+		if c >= '0' && c <= '9' {
+			continue
+		}
+		upper := digest[i] >= '8'
+		if upper != (c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
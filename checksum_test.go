@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestChecksumAtoms(t *testing.T) {
+	cases := []struct {
+		check func(any) bool
+		valid []string
+		bad   []string
+	}{
+		{IsCreditCard, []string{"4111111111111111", "4111 1111 1111 1111", "378282246310005"}, []string{"4111111111111112", "41111", "4111-1111-1111-111a"}},
+		{IsIBAN, []string{"GB82WEST12345698765432", "DE89 3704 0044 0532 0130 00"}, []string{"GB82WEST12345698765433", "DE8937040044053201300", "ZZ82WEST12345698765432"}},
+		{IsISBN10, []string{"0306406152", "080442957X"}, []string{"0306406153"}},
+		{IsISBN13, []string{"9780306406157"}, []string{"9780306406158"}},
+		{IsEAN13, []string{"4006381333931"}, []string{"4006381333932"}},
+		{IsEAN8, []string{"96385074"}, []string{"96385075"}},
+		{IsUPCA, []string{"036000291452"}, []string{"036000291453"}},
+		{IsISIN, []string{"US0378331005", "DE000BAY0017"}, []string{"US0378331006", "ZZ0378331005"}},
+		{IsVAT, []string{"DE136695976", "ATU13585627", "IT00743110157", "NL004495445B01", "BE0403019261", "FR40303265045", "ES B12345674"}, []string{"DE136695977", "ATU13585628", "IT00743110158", "XX123456789"}},
+		{IsBtcAddress, []string{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"}, []string{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3"}},
+		{IsBtcLowerAddress, []string{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", "bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297"}, []string{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdp"}},
+		{IsBtcUpperAddress, []string{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4"}, []string{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T5"}},
+		{IsEthAddress, []string{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"}, []string{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"}},
+	}
+	for _, c := range cases {
+		for _, v := range c.valid {
+			assert.True(t, c.check(v), "%s should be valid", v)
+		}
+		for _, v := range c.bad {
+			assert.False(t, c.check(v), "%s should be invalid", v)
+		}
+	}
+}
+
+func TestValidate_IsIBAN(t *testing.T) {
+	err := Get().Validate(map[string]string{
+		"iban": "GB82WEST12345698765433",
+	}, Rules{
+		".iban": "is:iban",
+	})
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, "is not one of the [iban]", err[0].Message)
+}
@@ -0,0 +1,53 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestLookupCountry(t *testing.T) {
+	for _, code := range []string{"US", "usa", "840"} {
+		c, ok := LookupCountry(code)
+		assert.True(t, ok, "%s should be found", code)
+		assert.Equal(t, Country{Alpha2: "US", Alpha3: "USA", Numeric: 840, Name: "United States"}, c)
+	}
+	c, ok := CountryByNumeric(156)
+	assert.True(t, ok)
+	assert.Equal(t, "CN", c.Alpha2)
+	_, ok = LookupCountry("ZZ")
+	assert.False(t, ok)
+	assert.Equal(t, len(iso3166_1_alpha2), len(Countries()))
+}
+
+func TestSubdivisionsAndCurrencies(t *testing.T) {
+	assert.Contains(t, Subdivisions("US"), "US-CA")
+	assert.Contains(t, Subdivisions("DEU"), "DE-BY")
+	assert.True(t, IsSubdivision("US-CA"))
+	assert.False(t, IsSubdivision("US-XX"))
+	assert.Equal(t, []string{"EUR"}, CurrenciesOf("DE"))
+	assert.Contains(t, CountriesOf("EUR"), "FR")
+	assert.True(t, IsCurrencyOf("CH", "CHF"))
+	assert.False(t, IsCurrencyOf("CH", "EUR"))
+	assert.True(t, IsLanguageTag("zh-Hans-CN"))
+	assert.True(t, IsLanguageTag("en"))
+	assert.False(t, IsLanguageTag("en_US_x!"))
+	assert.False(t, IsLanguageTag(""))
+}
+
+type PaymentCase struct {
+	Country  string `json:"country"`
+	Currency string `json:"currency" validate:"currency:field=country"`
+	State    string `validate:"is:subdivision;omitempty"`
+	Locale   string `validate:"is:languageTag;omitempty"`
+}
+
+func TestValidate_currencyOfCountry(t *testing.T) {
+	err := Get().Validate(PaymentCase{Country: "JP", Currency: "JPY", State: "JP-13", Locale: "ja-JP"})
+	assert.Nil(t, err)
+	err = Get().Validate(PaymentCase{Country: "JP", Currency: "USD", State: "JP-99"})
+	assert.True(t, len(err) == 2)
+	assert.Equal(t, "is not a currency of [JP]", err[0].Message)
+	assert.Equal(t, []string{".Currency"}, err[0].Fields)
+	assert.Equal(t, []string{".State"}, err[1].Fields)
+}
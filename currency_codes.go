@@ -1,5 +1,260 @@
 package validate
 
+import "strings"
+
+// currencies in use per iso 3166-1 alpha-2 code, taken from the CLDR data of golang.org/x/text
+var currenciesByCountry = map[string][]string{
+	"AF": {"AFN"},
+	"AX": {"EUR"},
+	"AL": {"ALL"},
+	"DZ": {"DZD"},
+	"AS": {"USD"},
+	"AD": {"EUR"},
+	"AO": {"AOA"},
+	"AI": {"XCD"},
+	"AG": {"XCD"},
+	"AR": {"ARS"},
+	"AM": {"AMD"},
+	"AW": {"AWG"},
+	"AU": {"AUD"},
+	"AT": {"EUR"},
+	"AZ": {"AZN"},
+	"BS": {"BSD"},
+	"BH": {"BHD"},
+	"BD": {"BDT"},
+	"BB": {"BBD"},
+	"BY": {"BYN"},
+	"BE": {"EUR"},
+	"BZ": {"BZD"},
+	"BJ": {"XOF"},
+	"BM": {"BMD"},
+	"BT": {"BTN", "INR"},
+	"BO": {"BOB"},
+	"BQ": {"USD"},
+	"BA": {"BAM"},
+	"BW": {"BWP"},
+	"BV": {"NOK"},
+	"BR": {"BRL"},
+	"IO": {"USD"},
+	"BN": {"BND"},
+	"BG": {"BGN"},
+	"BF": {"XOF"},
+	"BI": {"BIF"},
+	"KH": {"KHR"},
+	"CM": {"XAF"},
+	"CA": {"CAD"},
+	"CV": {"CVE"},
+	"KY": {"KYD"},
+	"CF": {"XAF"},
+	"TD": {"XAF"},
+	"CL": {"CLP"},
+	"CN": {"CNY"},
+	"CX": {"AUD"},
+	"CC": {"AUD"},
+	"CO": {"COP"},
+	"KM": {"KMF"},
+	"CG": {"XAF"},
+	"CD": {"CDF"},
+	"CK": {"NZD"},
+	"CR": {"CRC"},
+	"CI": {"XOF"},
+	"HR": {"EUR"},
+	"CU": {"CUC", "CUP"},
+	"CW": {"ANG"},
+	"CY": {"EUR"},
+	"CZ": {"CZK"},
+	"DK": {"DKK"},
+	"DJ": {"DJF"},
+	"DM": {"XCD"},
+	"DO": {"DOP"},
+	"EC": {"USD"},
+	"EG": {"EGP"},
+	"SV": {"USD"},
+	"GQ": {"XAF"},
+	"ER": {"ERN"},
+	"EE": {"EUR"},
+	"ET": {"ETB"},
+	"FK": {"FKP"},
+	"FO": {"DKK"},
+	"FJ": {"FJD"},
+	"FI": {"EUR"},
+	"FR": {"EUR"},
+	"GF": {"EUR"},
+	"PF": {"XPF"},
+	"TF": {"EUR"},
+	"GA": {"XAF"},
+	"GM": {"GMD"},
+	"GE": {"GEL"},
+	"DE": {"EUR"},
+	"GH": {"GHS"},
+	"GI": {"GIP"},
+	"GR": {"EUR"},
+	"GL": {"DKK"},
+	"GD": {"XCD"},
+	"GP": {"EUR"},
+	"GU": {"USD"},
+	"GT": {"GTQ"},
+	"GG": {"GBP"},
+	"GN": {"GNF"},
+	"GW": {"XOF"},
+	"GY": {"GYD"},
+	"HT": {"HTG", "USD"},
+	"HM": {"AUD"},
+	"VA": {"EUR"},
+	"HN": {"HNL"},
+	"HK": {"HKD"},
+	"HU": {"HUF"},
+	"IS": {"ISK"},
+	"IN": {"INR"},
+	"ID": {"IDR"},
+	"IR": {"IRR"},
+	"IQ": {"IQD"},
+	"IE": {"EUR"},
+	"IM": {"GBP"},
+	"IL": {"ILS"},
+	"IT": {"EUR"},
+	"JM": {"JMD"},
+	"JP": {"JPY"},
+	"JE": {"GBP"},
+	"JO": {"JOD"},
+	"KZ": {"KZT"},
+	"KE": {"KES"},
+	"KI": {"AUD"},
+	"KP": {"KPW"},
+	"KR": {"KRW"},
+	"KW": {"KWD"},
+	"KG": {"KGS"},
+	"LA": {"LAK"},
+	"LV": {"EUR"},
+	"LB": {"LBP"},
+	"LS": {"LSL", "ZAR"},
+	"LR": {"LRD"},
+	"LY": {"LYD"},
+	"LI": {"CHF"},
+	"LT": {"EUR"},
+	"LU": {"EUR"},
+	"MO": {"MOP"},
+	"MK": {"MKD"},
+	"MG": {"MGA"},
+	"MW": {"MWK"},
+	"MY": {"MYR"},
+	"MV": {"MVR"},
+	"ML": {"XOF"},
+	"MT": {"EUR"},
+	"MH": {"USD"},
+	"MQ": {"EUR"},
+	"MR": {"MRU"},
+	"MU": {"MUR"},
+	"YT": {"EUR"},
+	"MX": {"MXN"},
+	"FM": {"USD"},
+	"MD": {"MDL"},
+	"MC": {"EUR"},
+	"MN": {"MNT"},
+	"ME": {"EUR"},
+	"MS": {"XCD"},
+	"MA": {"MAD"},
+	"MZ": {"MZN"},
+	"MM": {"MMK"},
+	"NA": {"NAD", "ZAR"},
+	"NR": {"AUD"},
+	"NP": {"NPR"},
+	"NL": {"EUR"},
+	"NC": {"XPF"},
+	"NZ": {"NZD"},
+	"NI": {"NIO"},
+	"NE": {"XOF"},
+	"NG": {"NGN"},
+	"NU": {"NZD"},
+	"NF": {"AUD"},
+	"MP": {"USD"},
+	"NO": {"NOK"},
+	"OM": {"OMR"},
+	"PK": {"PKR"},
+	"PW": {"USD"},
+	"PS": {"ILS", "JOD"},
+	"PA": {"PAB", "USD"},
+	"PG": {"PGK"},
+	"PY": {"PYG"},
+	"PE": {"PEN"},
+	"PH": {"PHP"},
+	"PN": {"NZD"},
+	"PL": {"PLN"},
+	"PT": {"EUR"},
+	"PR": {"USD"},
+	"QA": {"QAR"},
+	"RE": {"EUR"},
+	"RO": {"RON"},
+	"RU": {"RUB"},
+	"RW": {"RWF"},
+	"BL": {"EUR"},
+	"SH": {"SHP"},
+	"KN": {"XCD"},
+	"LC": {"XCD"},
+	"MF": {"EUR"},
+	"PM": {"EUR"},
+	"VC": {"XCD"},
+	"WS": {"WST"},
+	"SM": {"EUR"},
+	"ST": {"STN"},
+	"SA": {"SAR"},
+	"SN": {"XOF"},
+	"RS": {"RSD"},
+	"SC": {"SCR"},
+	"SL": {"SLL"},
+	"SG": {"SGD"},
+	"SX": {"ANG"},
+	"SK": {"EUR"},
+	"SI": {"EUR"},
+	"SB": {"SBD"},
+	"SO": {"SOS"},
+	"ZA": {"ZAR"},
+	"GS": {"GBP"},
+	"SS": {"SSP"},
+	"ES": {"EUR"},
+	"LK": {"LKR"},
+	"SD": {"SDG"},
+	"SR": {"SRD"},
+	"SJ": {"NOK"},
+	"SZ": {"SZL"},
+	"SE": {"SEK"},
+	"CH": {"CHF"},
+	"SY": {"SYP"},
+	"TW": {"TWD"},
+	"TJ": {"TJS"},
+	"TZ": {"TZS"},
+	"TH": {"THB"},
+	"TL": {"USD"},
+	"TG": {"XOF"},
+	"TK": {"NZD"},
+	"TO": {"TOP"},
+	"TT": {"TTD"},
+	"TN": {"TND"},
+	"TR": {"TRY"},
+	"TM": {"TMT"},
+	"TC": {"USD"},
+	"TV": {"AUD"},
+	"UG": {"UGX"},
+	"UA": {"UAH"},
+	"AE": {"AED"},
+	"GB": {"GBP"},
+	"US": {"USD"},
+	"UM": {"USD"},
+	"UY": {"UYU"},
+	"UZ": {"UZS"},
+	"VU": {"VUV"},
+	"VE": {"VES"},
+	"VN": {"VND"},
+	"VG": {"USD"},
+	"VI": {"USD"},
+	"WF": {"XPF"},
+	"EH": {"MAD"},
+	"YE": {"YER"},
+	"ZM": {"ZMW"},
+	"ZW": {"USD", "ZWL"},
+	"XK": {"EUR"},
+}
+
 var iso4217 = map[string]bool{
 	"AFN": true, "EUR": true, "ALL": true, "DZD": true, "USD": true,
 	"AOA": true, "XCD": true, "ARS": true, "AMD": true, "AWG": true,
@@ -93,3 +348,41 @@ func IsCurrencyCodeNumeric(val any) bool {
 	}
 	return iso4217_numeric[code]
 }
+
+// CurrenciesOf lists the currencies legal tender in a country
+func CurrenciesOf(country string) []string {
+	c, ok := LookupCountry(country)
+	if !ok {
+		return nil
+	}
+	ret := make([]string, len(currenciesByCountry[c.Alpha2]))
+	copy(ret, currenciesByCountry[c.Alpha2])
+	return ret
+}
+
+// CountriesOf lists the alpha-2 codes of the countries using a currency
+func CountriesOf(currency string) []string {
+	var ret []string
+	currency = strings.ToUpper(currency)
+	for _, c := range countries {
+		for _, cur := range currenciesByCountry[c.Alpha2] {
+			if cur == currency {
+				ret = append(ret, c.Alpha2)
+			}
+		}
+	}
+	return ret
+}
+
+func IsCurrencyOf(country string, val any) bool {
+	code, ok := val.(string)
+	if !ok {
+		return false
+	}
+	for _, cur := range CurrenciesOf(country) {
+		if cur == code {
+			return true
+		}
+	}
+	return false
+}
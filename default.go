@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue parses raw into val, slices take comma separated elements
+func setValue(val reflect.Value, raw string) error {
+	switch val.Kind() {
+	case reflect.String:
+		val.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if val.Type() == durationType {
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return err
+			}
+			val.SetInt(int64(d))
+			return nil
+		}
+		i, err := strconv.ParseInt(raw, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		val.SetBool(b)
+	case reflect.Slice:
+		items := strings.Split(raw, ",")
+		s := reflect.MakeSlice(val.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setValue(s.Index(i), item); err != nil {
+				return err
+			}
+		}
+		val.Set(s)
+	case reflect.Struct:
+		o, ok := val.Addr().Interface().(interface{ parse(string) error })
+		if !ok {
+			return fmt.Errorf("can't parse a value for %s", val.Type())
+		}
+		return o.parse(raw)
+	case reflect.Ptr:
+		v := reflect.New(val.Type().Elem())
+		if err := setValue(v.Elem(), raw); err != nil {
+			return err
+		}
+		val.Set(v)
+	default:
+		return fmt.Errorf("can't parse a value for %s", val.Type())
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tj/assert"
+)
+
+type DefaultCase struct {
+	Host    string        `validate:"default:http://localhost:8080"`
+	Port    int           `validate:"default:8080;min:1"`
+	Ratio   float64       `validate:"default:0.5"`
+	Debug   bool          `validate:"default:true"`
+	Tags    []string      `validate:"default:a,b"`
+	Ports   []uint16      `validate:"default:80,443"`
+	Timeout time.Duration `validate:"default:1m30s"`
+	Retries *int          `validate:"default:3"`
+	Name    string
+}
+
+func TestValidate_default(t *testing.T) {
+	r := DefaultCase{Port: 9090, Name: "svc"}
+	err := Get().Validate(&r)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:8080", r.Host)
+	assert.Equal(t, 9090, r.Port)
+	assert.Equal(t, 0.5, r.Ratio)
+	assert.True(t, r.Debug)
+	assert.Equal(t, []string{"a", "b"}, r.Tags)
+	assert.Equal(t, []uint16{80, 443}, r.Ports)
+	assert.Equal(t, 90*time.Second, r.Timeout)
+	assert.Equal(t, 3, *r.Retries)
+
+	// values which can't be addressed keep being empty
+	err = Get().Validate(DefaultCase{Name: "svc"})
+	assert.True(t, len(err) == 5)
+	assert.Equal(t, []string{".Host"}, err[0].Fields)
+
+	m := struct{ Level string }{}
+	err = Get().Validate(&m, Rules{".Level": Rule{Default: "info"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "info", m.Level)
+}
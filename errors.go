@@ -0,0 +1,164 @@
+package validate
+
+// codes tell which rule a ValidateError comes from
+const (
+	CodeRequired = "required"
+	CodeCallback = "callback"
+	CodePhone    = "phone"
+	CodePostcode = "postcode"
+	CodeCurrency = "currency"
+	CodeIs       = "is"
+	CodeRegexp   = "regexp"
+	CodeEnum     = "enum"
+	CodeMin      = "min"
+	CodeMax      = "max"
+	CodeMust     = "must"
+	CodeType     = "type"
+	CodeMissing  = "missing"
+	CodeNull     = "null"
+	CodeSyntax   = "syntax"
+	CodeEmpty    = "empty"
+)
+
+// Unwrap gives the error returned by the callback of the rule
+func (v ValidateError) Unwrap() error {
+	return v.err
+}
+
+// Is matches a ValidateError target by its code, e.g. errors.Is(err, ValidateError{Code: CodeMin})
+func (v ValidateError) Is(target error) bool {
+	t, ok := target.(ValidateError)
+	return ok && t.Code != "" && t.Code == v.Code
+}
+
+// paths gives the paths of the error, parsing the fields when the error is built by hand
+func (v ValidateError) paths() []Path {
+	if len(v.Paths) == len(v.Fields) {
+		return v.Paths
+	}
+	ret := make([]Path, len(v.Fields))
+	for i, field := range v.Fields {
+		ret[i] = ParsePath(field)
+	}
+	return ret
+}
+
+// Err gives nil when there is no error, so the result compares to nil as an error.
+// a nil ValidateErrors put in an error interface is not nil
+func (errs ValidateErrors) Err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Unwrap lets errors.Is and errors.As look into every error, since go 1.20
+func (errs ValidateErrors) Unwrap() []error {
+	ret := make([]error, len(errs))
+	for i, err := range errs {
+		ret[i] = err
+	}
+	return ret
+}
+
+// Has tells if any error comes with the code
+func (errs ValidateErrors) Has(code string) bool {
+	for _, err := range errs {
+		if err.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// ByField groups the errors by field, an error of several fields is in the group of each
+func (errs ValidateErrors) ByField() map[string]ValidateErrors {
+	ret := make(map[string]ValidateErrors)
+	for _, err := range errs {
+		for _, field := range err.Fields {
+			ret[field] = append(ret[field], err)
+		}
+	}
+	return ret
+}
+
+// For keeps the errors of the field, which may be written in any path notation.
+// a segment may be the name, the go name or the json name of a field, or * for any
+func (errs ValidateErrors) For(field string) ValidateErrors {
+	p := ParsePath(field)
+	return errs.filter(func(q Path) bool {
+		return len(q) == len(p) && q.hasPrefix(p)
+	})
+}
+
+// Under keeps the errors of the field and the fields nested in it, the field may be written in any path notation
+func (errs ValidateErrors) Under(field string) ValidateErrors {
+	p := ParsePath(field)
+	return errs.filter(func(q Path) bool {
+		return q.hasPrefix(p)
+	})
+}
+
+func (errs ValidateErrors) filter(keep func(Path) bool) (ret ValidateErrors) {
+	for _, err := range errs {
+		for _, p := range err.paths() {
+			if keep(p) {
+				ret = append(ret, err)
+				break
+			}
+		}
+	}
+	return
+}
+
+func (p Path) hasPrefix(prefix Path) bool {
+	if len(p) < len(prefix) {
+		return false
+	}
+	for i, s := range prefix {
+		if !p[i].named(s.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrorTree mirrors the validated data, children are keyed by field name, index or map key
+type ErrorTree struct {
+	Errors   ValidateErrors        `json:"errors,omitempty"`
+	Children map[string]*ErrorTree `json:"children,omitempty"`
+}
+
+// Tree nests the errors by their paths, an error of several fields is put under each
+func (errs ValidateErrors) Tree() *ErrorTree {
+	root := &ErrorTree{}
+	for _, err := range errs {
+		for _, p := range err.paths() {
+			node := root
+			for _, s := range p {
+				if node.Children == nil {
+					node.Children = make(map[string]*ErrorTree)
+				}
+				child, ok := node.Children[s.Name]
+				if !ok {
+					child = &ErrorTree{}
+					node.Children[s.Name] = child
+				}
+				node = child
+			}
+			node.Errors = append(node.Errors, err)
+		}
+	}
+	return root
+}
+
+// Get finds the node of the field, which may be written in any path notation
+func (t *ErrorTree) Get(field string) *ErrorTree {
+	node := t
+	for _, s := range ParsePath(field) {
+		if node = node.Children[s.Name]; node == nil {
+			return nil
+		}
+	}
+	return node
+}
@@ -0,0 +1,80 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+var errOutOfStock = errors.New("out of stock")
+
+type ErrorsItem struct {
+	SKU string `validate:"min:3"`
+	Qty int    `validate:"min:1"`
+}
+
+type ErrorsCase struct {
+	Items []ErrorsItem
+	Note  string
+}
+
+func TestValidateErrors_views(t *testing.T) {
+	r := ErrorsCase{Items: []ErrorsItem{{SKU: "abc", Qty: 1}, {SKU: "a"}}}
+	err := Get(FieldPaths(JSONPointer)).Validate(r)
+	assert.True(t, len(err) == 3)
+	assert.True(t, err.Has(CodeMin))
+	assert.True(t, err.Has(CodeRequired))
+	assert.False(t, err.Has(CodeMax))
+
+	byField := err.ByField()
+	assert.Equal(t, 3, len(byField))
+	assert.Equal(t, CodeMin, byField["/Items/1/SKU"][0].Code)
+
+	assert.Equal(t, 1, len(err.For("/Items/1/SKU")))
+	assert.Equal(t, 1, len(err.For("$.Items[1].SKU")))
+	assert.Equal(t, 0, len(err.For("/Items/1")))
+	assert.Equal(t, 2, len(err.Under("/Items/1")))
+	assert.Equal(t, 2, len(err.Under(".Items")))
+	assert.Equal(t, 3, len(err.Under("")))
+
+	tree := err.Tree()
+	assert.Equal(t, 2, len(tree.Children))
+	assert.Equal(t, 1, len(tree.Children["Items"].Children))
+	assert.Equal(t, CodeRequired, tree.Get("Note").Errors[0].Code)
+	assert.Equal(t, CodeMin, tree.Get("/Items/1/Qty").Errors[0].Code)
+	assert.Nil(t, tree.Get("/Items/0"))
+
+	hand := ValidateErrors{{Fields: []string{".a.b"}, Message: "bad"}}
+	assert.Equal(t, 1, len(hand.Under(".a")))
+}
+
+type ErrorsUser struct {
+	UserName string       `json:"userName" validate:"min:3"`
+	Items    []ErrorsItem `json:"items"`
+}
+
+func TestValidateErrors_names(t *testing.T) {
+	err := Get(NameCase(SnakeCase)).Validate(ErrorsUser{UserName: "a", Items: []ErrorsItem{{SKU: "abc"}}})
+	assert.Equal(t, 2, len(err), err)
+	assert.Equal(t, []string{".user_name"}, err[0].Fields)
+	for _, name := range []string{".user_name", ".UserName", ".userName", "/userName"} {
+		assert.Equal(t, 1, len(err.For(name)), name)
+	}
+	assert.Equal(t, 1, len(err.Under("items")))
+	assert.Equal(t, 1, len(err.Under("$.Items[0]")))
+	assert.Equal(t, 1, len(err.For(".Items.*.Qty")))
+	assert.Equal(t, 0, len(err.For(".Items.*.SKU")))
+	assert.Equal(t, err.For(".userName"), Get(NameCase(SnakeCase), FieldMask("userName")).Validate(ErrorsUser{UserName: "a"}))
+}
+
+func TestValidateErrors_unwrap(t *testing.T) {
+	var err error = Get().Validate(map[string]string{"sku": "x", "name": ""}, Rules{
+		".sku": func(any) error { return errOutOfStock },
+	})
+	assert.True(t, errors.Is(err, errOutOfStock))
+	assert.True(t, errors.Is(err, ValidateError{Code: CodeRequired}))
+	assert.False(t, errors.Is(err, ValidateError{Code: CodeMin}))
+	var e ValidateError
+	assert.True(t, errors.As(err, &e))
+}
@@ -5,9 +5,11 @@ go 1.19
 require (
 	github.com/dev-mockingbird/logf v0.0.6
 	github.com/ettle/strcase v0.1.1
+	github.com/rivo/uniseg v0.4.4
 	github.com/spf13/cast v1.5.0
 	github.com/thoas/go-funk v0.9.3
 	github.com/tj/assert v0.0.3
+	golang.org/x/crypto v0.8.0
 	golang.org/x/text v0.9.0
 )
 
@@ -15,5 +17,6 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/testify v1.6.1 // indirect
+	golang.org/x/sys v0.7.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200605160147-a5ece683394c // indirect
 )
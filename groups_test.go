@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dev-mockingbird/logf"
+
+	"github.com/tj/assert"
+)
+
+type GroupUser struct {
+	ID   int64  `validate:"omitempty;required@update;empty@create"`
+	Name string `validate:"max:5;max@admin,import:10"`
+}
+
+func TestGroups(t *testing.T) {
+	u := GroupUser{Name: "abc"}
+	assert.Nil(t, Get().Validate(u).Err())
+	assert.Nil(t, Get(Groups("create")).Validate(u).Err())
+	errs := Get(Groups("update")).Validate(u)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, CodeRequired, errs[0].Code)
+
+	u.ID = 1
+	errs = Get(Groups("create")).Validate(u)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, CodeEmpty, errs[0].Code)
+	assert.Nil(t, Get(Groups("update")).Validate(u).Err())
+
+	u.Name = "abcdefg"
+	assert.True(t, Get(Groups("update")).Validate(u).Has(CodeMax))
+	assert.Nil(t, Get(Groups("update", "import")).Validate(u).Err())
+}
+
+func TestGroups_rules(t *testing.T) {
+	rules := Rules{".a": "min@strict:2;enum@loose:x,yy", ".b": "is@contact:email"}
+	data := map[string]string{"a": "x", "b": "b"}
+	assert.Nil(t, Get().Validate(data, rules).Err())
+	assert.True(t, Get(Groups("strict")).Validate(data, rules).Has(CodeMin))
+	assert.True(t, Get(Groups("contact")).Validate(data, rules).Has(CodeIs))
+	assert.Nil(t, Get(Groups("loose")).Validate(data, rules).Err())
+
+}
+
+type GroupAt struct {
+	Host  string `validate:"default:root@localhost;max@admin:20"`
+	Email string `validate:"regexp:^.+@example$;msg:Contact support@acme"`
+	Note  string `validate:"omitempty;min@draft,final:2;nope@draft"`
+}
+
+func TestGroups_at(t *testing.T) {
+	var logs []string
+	logger := logf.Logf(func(level logf.Level, format string, v ...any) {
+		logs = append(logs, fmt.Sprintf(format, v...))
+	})
+	g := GroupAt{Email: "a@example.com", Note: "x"}
+	errs := Get(Logger(logger)).Validate(&g)
+	assert.Equal(t, "root@localhost", g.Host)
+	assert.Equal(t, 1, len(errs), errs)
+	assert.Equal(t, CodeRegexp, errs[0].Code)
+	assert.Equal(t, "Contact support@acme", errs[0].Message)
+	assert.Equal(t, []string{"can't recognize scoped rule [nope@draft], it's left out"}, logs)
+	assert.True(t, Get(Groups("final")).Validate(&g).Has(CodeMin))
+
+	var rule Rule
+	ParseValidateTag(`regexp:^.+@example\.com$;required@update;default:a@b`, &rule, logf.New())
+	assert.Equal(t, `^.+@example\.com$`, rule.Regexp)
+	assert.Equal(t, "a@b", rule.Default)
+	assert.False(t, rule.Required)
+
+	logs, rule = nil, Rule{}
+	ParseValidateTag("min:3@admin;max:ten;max@admin:10", &rule, logger)
+	assert.Nil(t, rule.Min)
+	assert.Nil(t, rule.Max)
+	assert.Equal(t, []string{
+		"can't parse rule [min:3@admin], a scope goes right after the key like `min@group:...`, it's left out",
+		"can't parse rule [max:ten], it's left out",
+	}, logs)
+	assert.True(t, Get(Logger(logger)).Validate(map[string]int{"a": 0}, Rules{".a": "min:1@admin"}) == nil)
+}
@@ -0,0 +1,149 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// ErrTrailingData is given by Decode for a JSON body with more after its document
+var ErrTrailingData = errors.New("invalid data after top-level value")
+
+type contextKey[T any] struct{}
+
+// MaxBodySize is the most bytes Decode reads from a request body
+const MaxBodySize = 10 << 20
+
+// Decode reads the request into a T and validates it, messages follow the Accept-Language of the request.
+// a JSON body, a form or else the query is read, by the content type of the request, and fields are named by
+// the json, form or query tag in errors. defaults and transforms of the rules are applied to the T returned.
+// the body is read up to MaxBodySize, a JSON body must hold a single document.
+// the error is ValidateErrors when the request is read but invalid
+func Decode[T any](r *http.Request, opt ...Option) (T, error) {
+	return decode[T](nil, r, opt...)
+}
+
+// decode is Decode answering by w, which tells the server to close the connection of a body over MaxBodySize
+func decode[T any](w http.ResponseWriter, r *http.Request, opt ...Option) (T, error) {
+	var ret T
+	dst := reflect.ValueOf(&ret).Elem()
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, MaxBodySize)
+	}
+	switch {
+	case mediaType == "application/json" || mediaType == "" && r.ContentLength != 0:
+		v := validator{}.Config(requestOptions(r, append([]Option{NameTag("json")}, opt...))...)
+		dec := json.NewDecoder(r.Body)
+		err := dec.Decode(&ret)
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return ret, ValidateErrors{v.typeError(v.jsonPath(dst.Type(), typeErr.Field), typeErr.Type)}
+		} else if err != nil && err != io.EOF {
+			return ret, err
+		}
+		var tooLarge *http.MaxBytesError
+		if _, err := dec.Token(); errors.As(err, &tooLarge) {
+			return ret, err
+		} else if err != io.EOF {
+			return ret, ErrTrailingData
+		}
+		return ret, Check(v, &ret)
+	case mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data":
+		v := validator{}.Config(requestOptions(r, append([]Option{NameTag("form")}, opt...))...)
+		if err := r.ParseMultipartForm(32 << 20); err != nil && err != http.ErrNotMultipart {
+			return ret, err
+		}
+		if errs := v.bindValues(dst, r.Form, "form"); len(errs) > 0 {
+			return ret, errs
+		}
+		return ret, Check(v, &ret)
+	case mediaType == "":
+		v := validator{}.Config(requestOptions(r, append([]Option{NameTag("query")}, opt...))...)
+		if errs := v.bindValues(dst, r.URL.Query(), "query"); len(errs) > 0 {
+			return ret, errs
+		}
+		return ret, Check(v, &ret)
+	}
+	return ret, ErrUnsupportedMediaType
+}
+
+// Handler decodes and validates the request into a T before next, which gets it by FromContext.
+// an invalid request is answered by WriteError
+func Handler[T any](next http.Handler, opt ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v, err := decode[T](w, r, opt...)
+		if err != nil {
+			WriteError(w, r, err, opt...)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey[T]{}, v)))
+	})
+}
+
+// FromContext gives the T decoded by Handler
+func FromContext[T any](ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(contextKey[T]{}).(T)
+	return v, ok
+}
+
+// WriteError answers with a problem, 422 for ValidateErrors, 415 for ErrUnsupportedMediaType,
+// 413 for a body over MaxBodySize and 400 for others
+func WriteError(w http.ResponseWriter, r *http.Request, err error, opt ...Option) {
+	opt = requestOptions(r, opt)
+	var errs ValidateErrors
+	if errors.As(err, &errs) {
+		_ = NewProblem(http.StatusUnprocessableEntity, errs, opt...).Write(w)
+		return
+	}
+	status := http.StatusBadRequest
+	var tooLarge *http.MaxBytesError
+	if errors.Is(err, ErrUnsupportedMediaType) {
+		status = http.StatusUnsupportedMediaType
+	} else if errors.As(err, &tooLarge) {
+		status = http.StatusRequestEntityTooLarge
+	}
+	p := &Problem{
+		Title:         validator{}.Config(opt...).printer.Sprintf("Your request can't be decoded."),
+		Status:        status,
+		Detail:        err.Error(),
+		InvalidParams: []InvalidParam{},
+	}
+	_ = p.Write(w)
+}
+
+// jsonPath resolves the dotted json names of a decoding error to the fields of typ
+func (v *validator) jsonPath(typ reflect.Type, field string) (p Path) {
+	for _, name := range strings.Split(field, ".") {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		switch typ.Kind() {
+		case reflect.Struct:
+			f, ok := fieldByTagName(typ, name, "")
+			if !ok {
+				return p.append(guessSegment(name))
+			}
+			p, typ = p.field(f, v.fieldName(f)), f.Type
+		case reflect.Slice, reflect.Array:
+			p, typ = p.append(guessSegment(name)), typ.Elem()
+		case reflect.Map:
+			p, typ = p.key(name), typ.Elem()
+		default:
+			return p.append(guessSegment(name))
+		}
+	}
+	return
+}
+
+// requestOptions picks the language of messages from the Accept-Language of the request, opt may override it
+func requestOptions(r *http.Request, opt []Option) []Option {
+	return append([]Option{Accept(r.Header.Get("Accept-Language"))}, opt...)
+}
@@ -0,0 +1,168 @@
+package validate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+type SignUp struct {
+	Email string   `json:"email" validate:"is:email"`
+	Age   int      `json:"age" validate:"min:18"`
+	Tags  []string `json:"tags" validate:"omitempty"`
+}
+
+func signUpServer(t *testing.T) http.Handler {
+	return Handler[SignUp](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v, ok := FromContext[SignUp](r.Context())
+		assert.True(t, ok)
+		_ = json.NewEncoder(w).Encode(v)
+	}), FieldPaths(JSONPointer))
+}
+
+func serve(h http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler_json(t *testing.T) {
+	h := signUpServer(t)
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"a@b.com","age":20}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := serve(h, r)
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{"email":"a@b.com","age":20,"tags":null}`, w.Body.String())
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"a","age":20}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept-Language", "zh-CN,zh;q=0.9")
+	w = serve(h, r)
+	assert.Equal(t, 422, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	p, err := DecodeProblem(w.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, "请求参数校验失败。", p.Title)
+	assert.Equal(t, "/email", p.InvalidParams[0].Name)
+	assert.Equal(t, CodeIs, p.InvalidParams[0].Code)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"a@b.com","age":"20"}`))
+	w = serve(h, r)
+	assert.Equal(t, 422, w.Code)
+	p, _ = DecodeProblem(w.Body)
+	assert.Equal(t, CodeType, p.InvalidParams[0].Code)
+	assert.Equal(t, "should be of type [int]", p.InvalidParams[0].Reason)
+	assert.Equal(t, "/age", p.InvalidParams[0].Name)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"email":`))
+	r.Header.Set("Content-Type", "application/json")
+	w = serve(h, r)
+	assert.Equal(t, 400, w.Code)
+	p, _ = DecodeProblem(w.Body)
+	assert.Equal(t, "Your request can't be decoded.", p.Title)
+	assert.Equal(t, "unexpected EOF", p.Detail)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`<a/>`))
+	r.Header.Set("Content-Type", "application/xml")
+	assert.Equal(t, 415, serve(h, r).Code)
+}
+
+func TestHandler_form(t *testing.T) {
+	h := signUpServer(t)
+	r := httptest.NewRequest("POST", "/", strings.NewReader("email=a@b.com&age=30&tags=a&tags=b"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := serve(h, r)
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{"email":"a@b.com","age":30,"tags":["a","b"]}`, w.Body.String())
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader("email=a@b.com&age=old"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = serve(h, r)
+	assert.Equal(t, 422, w.Code)
+	p, _ := DecodeProblem(w.Body)
+	assert.Equal(t, "/Age", p.InvalidParams[0].Name)
+	assert.Equal(t, CodeType, p.InvalidParams[0].Code)
+
+	w = serve(h, httptest.NewRequest("GET", "/?email=a@b.com&age=18", nil))
+	assert.Equal(t, 200, w.Code)
+	w = serve(h, httptest.NewRequest("GET", "/?email=a@b.com&age=17", nil))
+	assert.Equal(t, 422, w.Code)
+	p, _ = DecodeProblem(w.Body)
+	assert.Equal(t, CodeMin, p.InvalidParams[0].Code)
+}
+
+func TestDecode(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"a@b.com","age":20}`))
+	v, err := Decode[*SignUp](r)
+	assert.Nil(t, err)
+	assert.Equal(t, 20, v.Age)
+}
+
+type Listen struct {
+	Email string `json:"email" validate:"trim;lower;is:email"`
+	Port  int    `json:"port" validate:"default:8080"`
+}
+
+func TestHandler_transform(t *testing.T) {
+	var got Listen
+	h := Handler[Listen](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = FromContext[Listen](r.Context())
+	}))
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"  A@B.COM "}`))
+	r.Header.Set("Content-Type", "application/json")
+	assert.Equal(t, 200, serve(h, r).Code)
+	assert.Equal(t, Listen{Email: "a@b.com", Port: 8080}, got)
+
+	v, err := Decode[*Listen](httptest.NewRequest("GET", "/?email=C@D.COM", nil))
+	assert.Nil(t, err)
+	assert.Equal(t, &Listen{Email: "c@d.com", Port: 8080}, v)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"`+strings.Repeat("a", MaxBodySize)+`"}`))
+	r.Header.Set("Content-Type", "application/json")
+	assert.Equal(t, 413, serve(h, r).Code)
+}
+
+func TestDecode_trailing(t *testing.T) {
+	for _, body := range []string{`{"email":"a@b.com","age":20}{"b":2}`, `{} junk`, `{"email":"a@b.com","age":20} 1`} {
+		_, err := Decode[SignUp](httptest.NewRequest("POST", "/", strings.NewReader(body)))
+		assert.Equal(t, ErrTrailingData, err, body)
+	}
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"a@b.com"} {}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := serve(signUpServer(t), r)
+	assert.Equal(t, 400, w.Code)
+	p, _ := DecodeProblem(w.Body)
+	assert.Equal(t, ErrTrailingData.Error(), p.Detail)
+	_, err := Decode[SignUp](httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"a@b.com","age":20}`+"\n")))
+	assert.Nil(t, err)
+}
+
+func TestHandler_tooLarge(t *testing.T) {
+	srv := httptest.NewServer(Handler[Listen](http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+	defer srv.Close()
+	body := `{"email":"` + strings.Repeat("a", MaxBodySize) + `"}`
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, 413, resp.StatusCode)
+	assert.True(t, resp.Close)
+}
+
+type Cart struct {
+	Items []struct {
+		Qty int `json:"qty"`
+	} `json:"items"`
+}
+
+func TestDecode_typeErrorPath(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"items":[{"qty":1},{"qty":"2"}]}`))
+	_, err := Decode[Cart](r, FieldPaths(JSONPath))
+	errs, ok := err.(ValidateErrors)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"$.items[1].qty"}, errs[0].Fields)
+	assert.Equal(t, "qty", errs[0].Paths[0][2].JSONName)
+}
@@ -0,0 +1,162 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+)
+
+// ValidateJSON validates a JSON document by rules without decoding it into go values.
+// rules match paths like Validate does, object members are map keys and array elements are indexes.
+// a member a rule names but the document lacks is reported as missing, a null as null and "", {} or [] as empty,
+// omitempty allows the three, `required` still rejects a missing member and `nullable` allows a null.
+// the document itself may be an empty object or array. a malformed document gives a CodeSyntax error with the byte offset in Params
+func ValidateJSON(raw []byte, rules Rules, opt ...Option) ValidateErrors {
+	v := validator{}.Config(opt...).With(rules)
+	w := jsonWalker{validator: v, dec: json.NewDecoder(bytes.NewReader(raw))}
+	for n := range v.rules {
+		w.patterns = append(w.patterns, ParsePath(n))
+	}
+	w.dec.UseNumber()
+	if _, err := w.value(Path{}); err != nil {
+		return append(w.errs, w.syntaxError(err))
+	}
+	if _, err := w.dec.Token(); err != io.EOF {
+		return append(w.errs, w.syntaxError(errors.New("invalid data after top-level value")))
+	}
+	return w.errs
+}
+
+type jsonWalker struct {
+	validator *validator
+	dec       *json.Decoder
+	patterns  []Path
+	path      Path
+	errs      ValidateErrors
+}
+
+// value walks the next value of the document at p, telling if it's null or empty
+func (w *jsonWalker) value(p Path) (empty bool, err error) {
+	w.path = p
+	token, err := w.dec.Token()
+	if err != nil {
+		return false, err
+	}
+	rule := w.validator.getRule(p, "")
+	switch t := token.(type) {
+	case json.Delim:
+		var n int
+		if t == '{' {
+			n, err = w.object(p)
+		} else {
+			n, err = w.array(p)
+		}
+		if err != nil {
+			return false, err
+		}
+		if n == 0 && len(p) > 0 && !rule.Omitempty {
+			w.errs = append(w.errs, rule.newError(p.String(), CodeRequired, nil, nil, w.validator.printer.Sprintf("not allow empty")))
+		}
+		return n == 0, nil
+	case nil:
+		if !rule.Omitempty && !rule.Nullable {
+			w.errs = append(w.errs, rule.newError(p.String(), CodeNull, nil, nil, w.validator.printer.Sprintf("should not be null")))
+		}
+		return true, nil
+	case json.Number:
+		if i, e := t.Int64(); e == nil {
+			token = i
+		} else if f, e := t.Float64(); e == nil {
+			token = f
+		}
+	}
+	rule.Required = false
+	empty, errs := rule.Validate(reflect.ValueOf(token), p.String())
+	w.errs = append(w.errs, errs...)
+	return empty, nil
+}
+
+func (w *jsonWalker) object(p Path) (int, error) {
+	present := make(map[string]bool)
+	must := make(map[string][]Path)
+	emptyes := make(map[string]bool)
+	for w.dec.More() {
+		key, err := w.dec.Token()
+		if err != nil {
+			return 0, err
+		}
+		name, _ := key.(string)
+		child := p.key(name)
+		empty, err := w.value(child)
+		if err != nil {
+			return 0, err
+		}
+		present[name] = true
+		emptyes[child.String()] = empty
+		for _, k := range w.validator.getRule(child, "").Must {
+			must[k] = append(must[k], child)
+		}
+	}
+	if _, err := w.dec.Token(); err != nil {
+		return 0, err
+	}
+	for _, child := range w.missing(p, present) {
+		rule := w.validator.getRule(child, "")
+		emptyes[child.String()] = true
+		for _, k := range rule.Must {
+			must[k] = append(must[k], child)
+		}
+		if !rule.Omitempty || rule.Required {
+			w.errs = append(w.errs, rule.newError(child.String(), CodeMissing, nil, nil, w.validator.printer.Sprintf("is missing")))
+		}
+	}
+	w.errs = append(w.errs, w.validator.mustErrors(must, emptyes)...)
+	return len(present), nil
+}
+
+// missing lists the members of the object at p the rules name but the object lacks
+func (w *jsonWalker) missing(p Path, present map[string]bool) (ret []Path) {
+	seen := make(map[string]bool)
+	for _, pattern := range w.patterns {
+		if len(pattern) != len(p)+1 || !p.match(pattern[:len(p)]) {
+			continue
+		}
+		name := pattern[len(p)].Name
+		if name == "*" || present[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+		ret = append(ret, p.key(name))
+	}
+	return
+}
+
+func (w *jsonWalker) array(p Path) (int, error) {
+	var n int
+	for ; w.dec.More(); n++ {
+		if _, err := w.value(p.index(n)); err != nil {
+			return 0, err
+		}
+	}
+	_, err := w.dec.Token()
+	return n, err
+}
+
+// syntaxError reports where the document can't be read
+func (w *jsonWalker) syntaxError(err error) ValidateError {
+	offset := w.dec.InputOffset()
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		offset = syntax.Offset
+	}
+	return ValidateError{
+		Fields:  []string{w.path.Format(w.validator.pathFormat)},
+		Paths:   []Path{w.path},
+		Code:    CodeSyntax,
+		Params:  offset,
+		Message: w.validator.printer.Sprintf("is malformed JSON at offset %d", offset),
+		err:     err,
+	}
+}
@@ -0,0 +1,92 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestValidateJSON(t *testing.T) {
+	raw := []byte(`{"name": "ab", "age": 17, "tags": ["x", ""], "items": [{"sku": "a"}, {}], "note": null}`)
+	errs := ValidateJSON(raw, Rules{
+		".name":         "min:3",
+		".age":          "min:18",
+		".items.*.sku":  "min:1",
+		".email":        "is:email",
+		".phone":        "omitempty",
+		"$.tags[*]":     "omitempty",
+		"/note":         "min:1",
+		"/items/1/note": "omitempty",
+	})
+	assert.Equal(t, 6, len(errs), errs)
+	for field, code := range map[string]string{
+		".name":        CodeMin,
+		".age":         CodeMin,
+		".items.1":     CodeRequired,
+		".items.1.sku": CodeMissing,
+		".email":       CodeMissing,
+		".note":        CodeNull,
+	} {
+		assert.True(t, errs.For(field).Has(code), field)
+	}
+	assert.Equal(t, KeySegment, errs.For(".items.1.sku")[0].Paths[0][0].Kind)
+	assert.Equal(t, IndexSegment, errs.For(".items.1.sku")[0].Paths[0][1].Kind)
+}
+
+func TestValidateJSON_empty(t *testing.T) {
+	rules := Rules{"a": "min:1"}
+	assert.True(t, ValidateJSON([]byte(`{}`), rules).Has(CodeMissing))
+	assert.True(t, ValidateJSON([]byte(`{"a": null}`), rules).Has(CodeNull))
+	assert.True(t, ValidateJSON([]byte(`{"a": ""}`), rules).Has(CodeRequired))
+	assert.True(t, ValidateJSON([]byte(`{"a": []}`), rules).Has(CodeRequired))
+	rules = Rules{"a": "omitempty"}
+	for _, raw := range []string{`{}`, `{"a": null}`, `{"a": ""}`, `{"a": {}}`} {
+		assert.Nil(t, ValidateJSON([]byte(raw), rules).Err(), raw)
+	}
+}
+
+func TestValidateJSON_float(t *testing.T) {
+	errs := ValidateJSON([]byte(`{"price": 0.5, "rate": 100.5, "step": 2.5, "ok": 1.0}`), Rules{
+		".price": "min:1",
+		".rate":  "max:100",
+		".step":  "enum:1,2.5",
+		".ok":    "enum:1,2",
+	})
+	assert.Equal(t, 2, len(errs), errs)
+	assert.True(t, errs.For(".price").Has(CodeMin))
+	assert.Equal(t, "should be greater than equal [1], current value is [0.5]", errs.For(".price")[0].Message)
+	assert.True(t, errs.For(".rate").Has(CodeMax))
+	assert.True(t, ValidateJSON([]byte(`{"step": 1.5}`), Rules{".step": "enum:1,2.5"}).Has(CodeEnum))
+
+	rules := Rules{".a": "min:0.5;max:9.99"}
+	assert.Nil(t, ValidateJSON([]byte(`{"a": 0.5}`), rules))
+	assert.Nil(t, ValidateJSON([]byte(`{"a": 9.99}`), rules))
+	assert.True(t, ValidateJSON([]byte(`{"a": 0.1}`), rules).Has(CodeMin))
+	errs = ValidateJSON([]byte(`{"a": 10.5}`), rules)
+	assert.True(t, errs.Has(CodeMax))
+	assert.Equal(t, "should be less than equal [9.99], current value is [10.5]", errs[0].Message)
+	assert.True(t, ValidateJSON([]byte(`{"a": 0}`), rules).Has(CodeMin))
+	assert.Nil(t, ValidateJSON([]byte(`{"a": 1}`), rules))
+}
+
+func TestValidateJSON_must(t *testing.T) {
+	rules := Rules{"email": "must:contact;omitempty", "phone": "must:contact;omitempty"}
+	errs := ValidateJSON([]byte(`{"email": ""}`), rules)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, CodeMust, errs[0].Code)
+	assert.Equal(t, 2, len(errs[0].Fields))
+	assert.Nil(t, ValidateJSON([]byte(`{"phone": "1"}`), rules).Err())
+}
+
+func TestValidateJSON_syntax(t *testing.T) {
+	errs := ValidateJSON([]byte(`{"a": {"b": tru}}`), nil, FieldPaths(JSONPointer))
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, CodeSyntax, errs[0].Code)
+	assert.Equal(t, []string{"/a/b"}, errs[0].Fields)
+	assert.Equal(t, int64(16), errs[0].Params)
+
+	errs = ValidateJSON([]byte(`{"a": "x"`), nil)
+	assert.True(t, errs.Has(CodeSyntax))
+	assert.Equal(t, int64(9), errs[len(errs)-1].Params)
+	assert.True(t, ValidateJSON([]byte(`{"a": "x"} 1`), nil).Has(CodeSyntax))
+}
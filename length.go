@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+type LengthUnit string
+
+const (
+	Runes     LengthUnit = "runes"     // unicode code points
+	Bytes     LengthUnit = "bytes"     // utf-8 encoded bytes
+	Graphemes LengthUnit = "graphemes" // user perceived characters, 👍🏽 counts as 1
+	Width     LengthUnit = "width"     // monospace display width, east asian wide characters count as 2
+)
+
+func (unit LengthUnit) Len(s string) int {
+	switch unit {
+	case Bytes:
+		return len(s)
+	case Graphemes:
+		return uniseg.GraphemeClusterCount(s)
+	case Width:
+		return uniseg.StringWidth(s)
+	}
+	return utf8.RuneCountInString(s)
+}
+
+// unit is the unit the rule counts lengths in, the one of the validator unless the rule has its own
+func (r Rule) unit() LengthUnit {
+	if r.Unit != "" {
+		return r.Unit
+	}
+	return r.validator.lengthUnit
+}
+
+func (r Rule) length(s string) int {
+	return r.unit().Len(s)
+}
+
+// minLength tells the minimum length in the unit of the rule
+func (r Rule) minLength(n int64) string {
+	switch r.unit() {
+	case Bytes:
+		return r.validator.printer.Sprintf("has a minimum length [%d] in bytes", n)
+	case Graphemes:
+		return r.validator.printer.Sprintf("has a minimum length [%d] in visible characters", n)
+	case Width:
+		return r.validator.printer.Sprintf("has a minimum display width [%d]", n)
+	}
+	return r.validator.printer.Sprintf("has a minimum length [%d]", n)
+}
+
+// maxLength tells the maximum length in the unit of the rule
+func (r Rule) maxLength(n int64) string {
+	switch r.unit() {
+	case Bytes:
+		return r.validator.printer.Sprintf("has a maximum length [%d] in bytes", n)
+	case Graphemes:
+		return r.validator.printer.Sprintf("has a maximum length [%d] in visible characters", n)
+	case Width:
+		return r.validator.printer.Sprintf("has a maximum display width [%d]", n)
+	}
+	return r.validator.printer.Sprintf("has a maximum length [%d]", n)
+}
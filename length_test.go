@@ -0,0 +1,54 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+	"golang.org/x/text/language"
+)
+
+func TestLengthUnit(t *testing.T) {
+	cases := []struct {
+		s     string
+		units map[LengthUnit]int
+	}{
+		{"hello", map[LengthUnit]int{Bytes: 5, Runes: 5, Graphemes: 5, Width: 5}},
+		{"张三丰", map[LengthUnit]int{Bytes: 9, Runes: 3, Graphemes: 3, Width: 6}},
+		{"👍🏽", map[LengthUnit]int{Bytes: 8, Runes: 2, Graphemes: 1, Width: 2}},
+		{"👨‍👩‍👧", map[LengthUnit]int{Bytes: 18, Runes: 5, Graphemes: 1, Width: 2}},
+		{"e\u0301", map[LengthUnit]int{Bytes: 3, Runes: 2, Graphemes: 1, Width: 1}},
+	}
+	for _, c := range cases {
+		for unit, n := range c.units {
+			assert.Equal(t, n, unit.Len(c.s), "%s of %q", unit, c.s)
+		}
+	}
+}
+
+type UsernameCase struct {
+	Name     string `validate:"min:2;max:10"`
+	Nickname string `validate:"max:4;unit:graphemes;omitempty"`
+}
+
+func TestValidate_lengthUnit(t *testing.T) {
+	r := UsernameCase{Name: "欧阳张三丰李四王五赵"}
+	err := Get().Validate(r)
+	assert.Nil(t, err)
+	err = Get(Length(Bytes)).Validate(r)
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, "has a maximum length of 10 bytes", err[0].Message)
+
+	r = UsernameCase{Name: "张三", Nickname: "👍🏽👍🏽👍🏽👍🏽"}
+	err = Get().Validate(r)
+	assert.Nil(t, err)
+	r.Nickname += "👍🏽"
+	err = Get().Validate(r)
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, []string{".Nickname"}, err[0].Fields)
+	assert.Equal(t, "has a maximum length of 4 visible characters", err[0].Message)
+	err = Get(Length(Width)).Validate(UsernameCase{Name: "张三丰李四王"})
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, "has a maximum display width of 10 columns", err[0].Message)
+	err = Get(Language(language.Chinese), Length(Bytes)).Validate(UsernameCase{Name: "a"})
+	assert.Equal(t, "长度不能少于2个字节", err[0].Message)
+}
@@ -0,0 +1,34 @@
+package validate
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message/catalog"
+)
+
+// Negotiate picks the language of the catalog best matching the tags, which are in order of preference.
+// english is picked when nothing matches
+func Negotiate(cat catalog.Catalog, tags ...language.Tag) language.Tag {
+	supported := cat.Languages()
+	if len(supported) == 0 || len(tags) == 0 {
+		return language.English
+	}
+	_, i, confidence := language.NewMatcher(supported).Match(tags...)
+	if confidence == language.No {
+		return language.English
+	}
+	return supported[i]
+}
+
+// Accept prints messages in the language of the catalog best matching an Accept-Language header, e.g. "zh-CN,zh;q=0.9,en;q=0.8".
+// a malformed header is ignored
+func Accept(header string) Option {
+	tags, _, _ := language.ParseAcceptLanguage(header)
+	return Prefer(tags...)
+}
+
+// Prefer prints messages in the language of the catalog best matching the tags, which are in order of preference
+func Prefer(tags ...language.Tag) Option {
+	return func(v *validator) {
+		v.accept = tags
+	}
+}
@@ -1,8 +1,20 @@
 package validate
 
 import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"testing/fstest"
 
+	translations "github.com/dev-mockingbird/validate/catalog"
+	"github.com/tj/assert"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 )
@@ -20,3 +32,233 @@ func TestI18n(t *testing.T) {
 	}
 	t.Fatal("translate failed")
 }
+
+type mustCase struct {
+	ID    string `validate:"must:id;omitempty"`
+	Email string `validate:"must:id;omitempty"`
+}
+
+type localeCase struct {
+	data  any
+	rules Rules
+	key   string
+	args  []any
+}
+
+var localeCases = []localeCase{
+	{map[string]string{"a": ""}, Rules{".a": "is:email"}, "not allow empty", nil},
+	{map[string]string{"a": "abc"}, Rules{".a": "phone:GB"}, "is not a valid phone number", nil},
+	{map[string]string{"a": "abc"}, Rules{".a": "postcode:GB"}, "is not a valid postal code", nil},
+	{map[string]string{"a": "EUR"}, Rules{".a": "currency:US"}, "is not a currency of [%s]", []any{"US"}},
+	{map[string]string{"a": "abc"}, Rules{".a": "is:email"}, "is not one of the [%s]", []any{"email"}},
+	{map[string]string{"a": "abc"}, Rules{".a": "regexp:("}, "can't compile regexp: %s", []any{"error parsing regexp: missing closing ): `(`"}},
+	{map[string]string{"a": "abc"}, Rules{".a": "regexp:^x$"}, "cound be malformed", nil},
+	{map[string]string{"a": "c"}, Rules{".a": "enum:a,b"}, "should be one of [%s], current value is [%s]", []any{"a,b", "c"}},
+	{map[string]string{"a": "ab"}, Rules{".a": "min:5"}, "has a minimum length [%d]", []any{int64(5)}},
+	{map[string]string{"a": "ab"}, Rules{".a": "max:1"}, "has a maximum length [%d]", []any{int64(1)}},
+	{map[string]string{"a": "ab"}, Rules{".a": "min:5;unit:bytes"}, "has a minimum length [%d] in bytes", []any{int64(5)}},
+	{map[string]string{"a": "ab"}, Rules{".a": "max:1;unit:bytes"}, "has a maximum length [%d] in bytes", []any{int64(1)}},
+	{map[string]string{"a": "ab"}, Rules{".a": "min:5;unit:graphemes"}, "has a minimum length [%d] in visible characters", []any{int64(5)}},
+	{map[string]string{"a": "ab"}, Rules{".a": "max:1;unit:graphemes"}, "has a maximum length [%d] in visible characters", []any{int64(1)}},
+	{map[string]string{"a": "ab"}, Rules{".a": "min:5;unit:width"}, "has a minimum display width [%d]", []any{int64(5)}},
+	{map[string]string{"a": "ab"}, Rules{".a": "max:1;unit:width"}, "has a maximum display width [%d]", []any{int64(1)}},
+	{map[string]int{"a": 3}, Rules{".a": "enum:1,2"}, "should be one of [%s], current value is [%d]", []any{"1,2", int64(3)}},
+	{map[string]int{"a": 3}, Rules{".a": "min:5"}, "should be greater than equal [%d], current value is [%d]", []any{int64(5), int64(3)}},
+	{map[string]int{"a": 3}, Rules{".a": "max:1"}, "should be less than equal [%d], current value is [%d]", []any{int64(1), int64(3)}},
+	{map[string]float64{"a": 1.5}, Rules{".a": "enum:1,2"}, "should be one of [%s], current value is [%v]", []any{"1,2", 1.5}},
+	{map[string]float64{"a": 1.5}, Rules{".a": "min:5.5"}, "should be greater than equal [%v], current value is [%v]", []any{5.5, 1.5}},
+	{map[string]float64{"a": 1.5}, Rules{".a": "max:1"}, "should be less than equal [%v], current value is [%v]", []any{1.0, 1.5}},
+	{mustCase{}, nil, "at least one of the fields should be valued", nil},
+	{map[string]string{"a": "x"}, Rules{".a": "empty"}, "should be empty", nil},
+}
+
+// otherCases are printed outside of validation rules
+var otherCases = []struct {
+	key  string
+	args []any
+	emit func() string
+}{
+	{"Your request parameters didn't validate.", nil, func() string { return NewProblem(422, nil).Title }},
+	{"should be of type [%s]", []any{"int"}, func() string {
+		return Get().(*validator).typeError(Path{}, reflect.TypeOf(0)).Message
+	}},
+	{"Your request can't be decoded.", nil, func() string {
+		w := httptest.NewRecorder()
+		WriteError(w, httptest.NewRequest("POST", "/", nil), errors.New("bad"))
+		p, _ := DecodeProblem(w.Body)
+		return p.Title
+	}},
+	{"is missing", nil, func() string { return ValidateJSON([]byte(`{}`), Rules{"a": "min:1"})[0].Message }},
+	{"should not be null", nil, func() string { return ValidateJSON([]byte(`null`), nil)[0].Message }},
+	{"is malformed JSON at offset %d", []any{int64(6)}, func() string { return ValidateJSON([]byte(`{"a" 1}`), nil)[0].Message }},
+}
+
+type nopRenderer struct{}
+
+func (nopRenderer) Arg(int) interface{} { return nil }
+func (nopRenderer) Render(string)       {}
+
+func TestI18n_coverage(t *testing.T) {
+	en := message.NewPrinter(language.English, message.Catalog(translations.Default))
+	translated := func(key string) {
+		for _, tag := range translations.Default.Languages() {
+			if err := translations.Default.Context(tag, nopRenderer{}).Execute(key); err != nil {
+				t.Errorf("[%s] has no translation for %s: %s", key, tag, err)
+			}
+		}
+	}
+	for _, c := range localeCases {
+		var rules []Rules
+		if c.rules != nil {
+			rules = append(rules, c.rules)
+		}
+		errs := Get().Validate(c.data, rules...)
+		if len(errs) == 0 || errs[len(errs)-1].Message != en.Sprintf(c.key, c.args...) {
+			t.Fatalf("[%s] is not emitted, got %v", c.key, errs)
+		}
+		translated(c.key)
+	}
+	for _, c := range otherCases {
+		if c.emit() != en.Sprintf(c.key, c.args...) {
+			t.Fatalf("[%s] is not emitted", c.key)
+		}
+		translated(c.key)
+	}
+}
+
+// TestI18n_keys makes sure every message printed by the package is covered above
+func TestI18n_keys(t *testing.T) {
+	covered := make(map[string]bool)
+	for _, c := range localeCases {
+		covered[c.key] = true
+	}
+	for _, c := range otherCases {
+		covered[c.key] = true
+	}
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range pkgs {
+		ast.Inspect(pkg, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Sprintf" {
+				return true
+			}
+			if x, ok := sel.X.(*ast.SelectorExpr); !ok || x.Sel.Name != "printer" {
+				return true
+			}
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok {
+				key, _ := strconv.Unquote(lit.Value)
+				if !covered[key] {
+					t.Errorf("[%s] at %s is not covered", key, fset.Position(lit.Pos()))
+				}
+			}
+			return true
+		})
+	}
+}
+
+func TestI18n_bundled(t *testing.T) {
+	assert.Equal(t, 6, len(translations.Default.Languages()))
+	assert.Equal(t, 6, len(message.DefaultCatalog.Languages()))
+	for tag, msg := range map[language.Tag]string{
+		language.Japanese: "5文字以上で入力してください",
+		language.Spanish:  "tiene una longitud mínima de 5 caracteres",
+		language.German:   "hat eine Mindestlänge von 5 Zeichen",
+		language.French:   "a une longueur minimale de 5 caractères",
+	} {
+		err := Get(Language(tag)).Validate(map[string]string{"a": "ab"}, Rules{".a": "min:5"})
+		assert.Equal(t, msg, err[0].Message)
+	}
+}
+
+func TestI18n_load(t *testing.T) {
+	cat, err := translations.New()
+	assert.Nil(t, err)
+	assert.Nil(t, translations.Load(cat, fstest.MapFS{
+		"i18n/zh.json": {Data: []byte(`{
+			"language": "zh",
+			"messages": [
+				{"id": "not allow empty", "message": "not allow empty", "translation": "必填"},
+				{"id": "Discount %", "message": "Discount %", "translation": "折扣 %"},
+				{
+					"id": "{N}% off",
+					"message": "{N}% off",
+					"translation": "{N}% 折扣",
+					"placeholders": [{"id": "N", "string": "%[1]d", "type": "int", "argNum": 1}]
+				},
+				{
+					"id": "has a minimum length [{Min}]",
+					"message": "has a minimum length [{Min}]",
+					"translation": "至少{Min}个字",
+					"placeholders": [{"id": "Min", "string": "%[1]d", "type": "int64", "argNum": 1}]
+				}
+			]
+		}`)},
+		"i18n/pt.json": {Data: []byte(`{"language": "pt", "messages": [{"id": "not allow empty", "message": "not allow empty", "translation": "não pode ficar vazio"}]}`)},
+	}, "i18n/*.json"))
+	va := Get(Catalog(cat), Language(language.Chinese))
+	errs := va.Validate(map[string]string{"a": ""}, Rules{".a": ""})
+	assert.Equal(t, "必填", errs[0].Message)
+	errs = va.Validate(map[string]string{"a": "ab"}, Rules{".a": "min:5"})
+	assert.Equal(t, "至少5个字", errs[0].Message)
+	errs = va.Validate(map[string]string{"a": "abc"}, Rules{".a": "postcode:GB"})
+	assert.Equal(t, "不是有效的邮政编码", errs[0].Message)
+	errs = va.Validate(map[string]string{"a": ""}, Rules{".a": "label:Discount %;msg:{label} 100%"})
+	assert.Equal(t, "折扣 %", errs[0].Label)
+	assert.Equal(t, "折扣 % 100%", errs[0].Message)
+	assert.Equal(t, "5% 折扣", message.NewPrinter(language.Chinese, message.Catalog(cat)).Sprintf("%d%% off", 5))
+	errs = Get(Catalog(cat), Language(language.Portuguese)).Validate(map[string]string{"a": ""}, Rules{".a": ""})
+	assert.Equal(t, "não pode ficar vazio", errs[0].Message)
+}
+
+func TestI18n_plural(t *testing.T) {
+	for _, c := range []struct {
+		tag  language.Tag
+		data any
+		rule string
+		msg  string
+	}{
+		{language.English, map[string]string{"a": "ab"}, "min:5", "has a minimum length of 5 characters"},
+		{language.English, map[string]string{"a": "ab"}, "max:1", "has a maximum length of 1 character"},
+		{language.English, map[string]int{"a": 2}, "min:10000", "should be greater than equal [10,000], current value is [2]"},
+		{language.English, map[string]int{"a": 12345}, "max:10", "should be less than equal [10], current value is [12,345]"},
+		{language.Chinese, map[string]string{"a": "ab"}, "min:5", "长度不能少于5个字符"},
+		{language.Chinese, map[string]string{"a": "ab"}, "max:1", "长度不能超过1个字符"},
+		{language.Chinese, map[string]int{"a": 2}, "min:10000", "应该大于等于[10,000]，当前值为[2]"},
+		{language.French, map[string]string{"a": "ab"}, "max:1", "a une longueur maximale de 1 caractère"},
+		{language.German, map[string]int{"a": 12345}, "max:10", "muss kleiner oder gleich [10] sein, aktueller Wert ist [12.345]"},
+		{language.Chinese, map[string]float64{"a": 0.5}, "min:1", "应该大于等于[1]，当前值为[0.5]"},
+	} {
+		errs := Get(Language(c.tag)).Validate(c.data, Rules{".a": c.rule})
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, c.msg, errs[0].Message)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	for header, tag := range map[string]language.Tag{
+		"zh-CN,zh;q=0.9,en;q=0.8":  language.Chinese,
+		"fr-CH, fr;q=0.9, *;q=0.5": language.French,
+		"pt-BR,de;q=0.7":           language.German,
+		"en-GB":                    language.English,
+		"pt-BR":                    language.English,
+		"":                         language.English,
+		"!!!":                      language.English,
+	} {
+		tags, _, _ := language.ParseAcceptLanguage(header)
+		assert.Equal(t, tag, Negotiate(translations.Default, tags...), header)
+	}
+	errs := Get(Accept("ja-JP,en;q=0.5")).Validate(map[string]string{"a": ""}, Rules{".a": ""})
+	assert.Equal(t, "空にできません", errs[0].Message)
+	errs = Get(Prefer(language.MustParse("es-MX"))).Validate(map[string]string{"a": ""}, Rules{".a": ""})
+	assert.Equal(t, "no puede estar vacío", errs[0].Message)
+}
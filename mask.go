@@ -0,0 +1,134 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldMask limits validation to the fields at paths and the fields nested in them, e.g. the fields a PATCH request updates.
+// paths may be written in any path notation with `*` segments, a segment matches a field by its resolved, go or json name.
+// a field out of the mask is still validated when its rule refers to a field in the mask by `field=<name>`,
+// and a must group is checked when any of its fields is in the mask, taking the others as they are
+func FieldMask(paths ...string) Option {
+	return func(v *validator) {
+		v.masked = true
+		for _, p := range paths {
+			v.mask = append(v.mask, ParsePath(p))
+		}
+	}
+}
+
+// ValidatePartial validates only the fields of data at paths and the fields nested in them, see FieldMask
+func ValidatePartial(data any, paths []string, opt ...Option) ValidateErrors {
+	return Get(append(opt, FieldMask(paths...))...).Validate(data)
+}
+
+// covers tells if the value at p is validated by its rule, parent is the value holding it
+func (validator *validator) covers(p Path, rule Rule, parent reflect.Value) bool {
+	if !validator.masked {
+		return true
+	}
+	for _, m := range validator.mask {
+		if len(m) <= len(p) && p[:len(m)].masked(m) {
+			return true
+		}
+	}
+	for _, param := range []string{rule.Postcode, rule.Currency} {
+		name := strings.TrimPrefix(param, "field=")
+		if name == param || len(p) == 0 {
+			continue
+		}
+		if sp, ok := validator.siblingPath(parent, p[:len(p)-1], name); ok && validator.covers(sp, Rule{}, parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// leads tells if some field in the mask is nested in the value at p
+func (validator *validator) leads(p Path) bool {
+	for _, m := range validator.mask {
+		if len(m) > len(p) && p.masked(m[:len(p)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// descend walks into a value out of the mask to reach the fields in the mask, leaving its own rule out
+func (validator *validator) descend(val reflect.Value, p Path) ValidateErrors {
+	if !validator.leads(p) {
+		return nil
+	}
+	for {
+		if o, ok := asOptional(val); ok {
+			if set, null := o.state(); !set || null {
+				return nil
+			}
+			val = val.Field(0)
+		} else if val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return nil
+			}
+			val = val.Elem()
+		} else {
+			break
+		}
+	}
+	switch val.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Map:
+		return validator.validateReflectValue(val, p)
+	}
+	return nil
+}
+
+// siblingPath is the path of the field called name next to the validated one, under the path of the parent
+func (validator *validator) siblingPath(parent reflect.Value, p Path, name string) (Path, bool) {
+	for parent.IsValid() && (parent.Kind() == reflect.Ptr || parent.Kind() == reflect.Interface) {
+		parent = parent.Elem()
+	}
+	if !parent.IsValid() {
+		return nil, false
+	}
+	switch parent.Kind() {
+	case reflect.Struct:
+		if f, ok := validator.siblingField(parent.Type(), name); ok {
+			return p.field(f, validator.fieldName(f)), true
+		}
+	case reflect.Map:
+		return p.key(name), true
+	}
+	return nil, false
+}
+
+// masked tells if the mask path m matches p segment by segment, by resolved, go or json name or `*`
+func (p Path) masked(m Path) bool {
+	if len(p) != len(m) {
+		return false
+	}
+	for i, s := range m {
+		if !p[i].named(s.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmpty tells if a value out of the mask is empty for its must groups, as its rule would tell
+func isEmpty(val reflect.Value) bool {
+	if o, ok := asOptional(val); ok {
+		if set, null := o.state(); !set || null {
+			return true
+		}
+		return isEmpty(val.Field(0))
+	}
+	switch val.Kind() {
+	case reflect.Ptr:
+		return val.IsNil() || isEmpty(val.Elem())
+	case reflect.Interface:
+		return val.IsNil()
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return val.Len() == 0
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+type MaskAddress struct {
+	Country  string `json:"country" validate:"enum:CN,GB"`
+	Postcode string `json:"postcode" validate:"postcode:field=country"`
+}
+
+type MaskUser struct {
+	Name    string       `json:"name" validate:"min:2"`
+	Email   string       `json:"email" validate:"must:contact;omitempty"`
+	Phone   string       `json:"phone" validate:"must:contact;omitempty"`
+	Address *MaskAddress `json:"address"`
+	Tags    []string     `json:"tags"`
+}
+
+func TestValidatePartial(t *testing.T) {
+	u := MaskUser{Name: "a", Address: &MaskAddress{Country: "GB", Postcode: "x"}, Tags: []string{"", "b"}}
+	assert.Equal(t, 4, len(Get().Validate(u)))
+
+	errs := ValidatePartial(u, []string{"name"})
+	assert.Equal(t, 1, len(errs), errs)
+	assert.True(t, errs.For(".Name").Has(CodeMin))
+
+	errs = ValidatePartial(u, []string{"/address/postcode", "$.tags[*]"})
+	assert.Equal(t, 2, len(errs), errs)
+	assert.True(t, errs.For(".Address.Postcode").Has(CodePostcode))
+	assert.True(t, errs.For(".Tags.0").Has(CodeRequired))
+
+	errs = ValidatePartial(u, []string{".Address"})
+	assert.Equal(t, 1, len(errs), errs)
+	assert.Nil(t, ValidatePartial(u, nil).Err())
+}
+
+func TestValidatePartial_crossField(t *testing.T) {
+	u := MaskUser{Name: "ab", Email: "a@b.c", Address: &MaskAddress{Country: "GB", Postcode: "x"}}
+	errs := ValidatePartial(u, []string{"address.country"})
+	assert.Equal(t, 1, len(errs), errs)
+	assert.True(t, errs.For(".Address.Postcode").Has(CodePostcode))
+}
+
+func TestValidatePartial_must(t *testing.T) {
+	u := MaskUser{Name: "ab"}
+	assert.Nil(t, ValidatePartial(u, []string{"name"}).Err())
+	errs := ValidatePartial(u, []string{"email"})
+	assert.Equal(t, 1, len(errs), errs)
+	assert.Equal(t, CodeMust, errs[0].Code)
+	assert.Equal(t, []string{".Email", ".Phone"}, errs[0].Fields)
+
+	u.Phone = "1"
+	assert.Nil(t, ValidatePartial(u, []string{"email"}).Err())
+	assert.Nil(t, Get(FieldMask("email", "phone")).Validate(u).Err())
+}
@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tj/assert"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+type MessageCase struct {
+	Email string `validate:"is:email;msg:Please enter a valid work email"`
+	Age   int    `validate:"min:18;msg:{field} must be at least {params}, got {value}"`
+}
+
+func TestValidate_customMessage(t *testing.T) {
+	err := Get().Validate(MessageCase{Email: "john", Age: 16})
+	assert.True(t, len(err) == 2)
+	assert.Equal(t, "Please enter a valid work email", err[0].Message)
+	assert.Equal(t, ".Age must be at least 18, got 16", err[1].Message)
+
+	err = Get().Validate(map[string]string{"code": ""}, Rules{
+		".code": Rule{Message: "code is required"},
+	})
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, "code is required", err[0].Message)
+
+	err = Get().Validate(map[string]string{"code": "x"}, Rules{
+		".code": Rule{Message: "bad code {value}", Callback: func(any) error { return errors.New("hello") }},
+	})
+	assert.Equal(t, "bad code x", err[0].Message)
+
+	err = Get().Validate(map[string]int{"rate": 120}, Rules{".rate": "max:100;msg:must be 100% valid, got {value}%"})
+	assert.Equal(t, "must be 100% valid, got 120%", err[0].Message)
+}
+
+func TestValidate_customMessageTranslated(t *testing.T) {
+	cat := catalog.NewBuilder()
+	assert.Nil(t, cat.SetString(language.Chinese, "Please enter a valid work email", "请输入有效的工作邮箱"))
+	err := Get(Printer(message.NewPrinter(language.Chinese, message.Catalog(cat)))).Validate(MessageCase{Email: "john", Age: 20})
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, "请输入有效的工作邮箱", err[0].Message)
+
+	assert.Nil(t, cat.SetString(language.Chinese, "must be 100% valid, got {value}%", "必须100%%有效，当前为{value}%%"))
+	va := Get(Printer(message.NewPrinter(language.Chinese, message.Catalog(cat))))
+	err = va.Validate(map[string]int{"rate": 120}, Rules{".rate": "max:100;msg:must be 100% valid, got {value}%"})
+	assert.Equal(t, "必须100%有效，当前为120%", err[0].Message)
+	err = va.Validate(map[string]int{"rate": 120}, Rules{".rate": "max:100;msg:at most 100%"})
+	assert.Equal(t, "at most 100%", err[0].Message)
+}
+
+type LabelCase struct {
+	EmailAddr string `validate:"label:Email address"`
+	Nickname  string `validate:"label:Nickname;msg:{label} is required"`
+	Password  string
+}
+
+func TestValidate_label(t *testing.T) {
+	err := Get().Validate(LabelCase{})
+	assert.True(t, len(err) == 3)
+	assert.Equal(t, "Email address", err[0].Label)
+	assert.Equal(t, []string{".EmailAddr"}, err[0].Fields)
+	assert.Equal(t, "Email address not allow empty", err[0].Error())
+	assert.Equal(t, "Nickname is required", err[1].Message)
+	assert.Equal(t, "", err[2].Label)
+	assert.Equal(t, "`.Password` not allow empty", err[2].Error())
+
+	cat := catalog.NewBuilder()
+	assert.Nil(t, cat.SetString(language.Chinese, "Email address", "邮箱地址"))
+	assert.Nil(t, cat.SetString(language.Chinese, "Password", "密码"))
+	err = Get(
+		Printer(message.NewPrinter(language.Chinese, message.Catalog(cat))),
+		Labeler(func(field string) string {
+			return map[string]string{".Password": "Password"}[field]
+		}),
+	).Validate(LabelCase{Nickname: "n"})
+	assert.True(t, len(err) == 2)
+	assert.Equal(t, "邮箱地址", err[0].Label)
+	assert.Equal(t, "密码", err[1].Label)
+
+	err = Get().Validate(map[string]string{"discount": ""}, Rules{".discount": "label:Discount %"})
+	assert.Equal(t, "Discount %", err[0].Label)
+	assert.Equal(t, "Discount % not allow empty", err[0].Error())
+}
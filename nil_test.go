@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+var errNotFound = errors.New("not found")
+
+func validOrNot(data any, rules ...Rules) error {
+	return Get().Validate(data, rules...)
+}
+
+func TestCheck_nil(t *testing.T) {
+	ok := map[string]string{"a": "x"}
+	bad := map[string]string{"a": ""}
+
+	// the trap, a nil ValidateErrors in an error is not nil
+	assert.True(t, validOrNot(ok) != nil)
+
+	assert.Nil(t, Check(Get(), ok))
+	assert.True(t, Check(Get(), ok) == nil)
+	err := Check(Get(), bad)
+	assert.NotNil(t, err)
+	var errs ValidateErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, 1, len(errs))
+
+	assert.True(t, Check(Get(), nil) == nil)
+	assert.True(t, Check(Get(), (*LabelCase)(nil)) == nil)
+	assert.Nil(t, Get().Validate(nil))
+
+	assert.True(t, ValidateErrors(nil).Err() == nil)
+	assert.True(t, ValidateErrors{}.Err() == nil)
+	assert.NotNil(t, errs.Err())
+}
+
+// validateOnly is a Validator of another package, knowing nothing of Check
+type validateOnly struct{ Validator }
+
+func TestAdapters(t *testing.T) {
+	ok := map[string]string{"a": "x"}
+	bad := map[string]string{"a": ""}
+
+	assert.True(t, Check(validateOnly{Get()}, ok) == nil)
+	assert.NotNil(t, Check(validateOnly{Get()}, bad))
+	assert.True(t, Func(validateOnly{Get()})(ok) == nil)
+
+	fn := Func(Get())
+	assert.True(t, fn(ok) == nil)
+	assert.True(t, fn.Validate(ok) == nil)
+	assert.NotNil(t, fn(bad))
+
+	va := Validate(validOrNot).Validator()
+	assert.True(t, Check(va, ok) == nil)
+	assert.Nil(t, va.Validate(ok))
+	assert.Equal(t, 1, len(va.Validate(bad)))
+	assert.NotNil(t, Check(va, bad))
+
+	va = Validate(func(any, ...Rules) error { return errNotFound }).Validator()
+	errs := va.Validate(ok)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "not found", errs[0].Message)
+	assert.True(t, errors.Is(Check(va, ok), errNotFound))
+	assert.True(t, errors.Is(errs, errNotFound))
+
+	va = Validate(func(any, ...Rules) error { return ValidateError{Fields: []string{".a"}, Message: "bad"} }).Validator()
+	assert.Equal(t, []string{".a"}, va.Validate(ok)[0].Fields)
+
+	va = Validate(func(any, ...Rules) error { return nil }).Validator()
+	assert.Nil(t, va.Validate(ok))
+	assert.True(t, Check(va, ok) == nil)
+}
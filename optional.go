@@ -0,0 +1,80 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// Optional is a value that may be absent or null, e.g. a field of a PATCH request.
+// the zero value is absent, decoding JSON makes it set, and null when the JSON is null.
+// the validator checks Value by the rule of the field when it's set and not null,
+// `required` rejects an absent value and `nullable` allows a null one.
+// a set Value is present even if it's empty, e.g. "" passes `required` but not `min:1`, like the value of a pointer
+type Optional[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+// Some gives a set Optional of v
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Set: true}
+}
+
+// Null gives a set Optional of null
+func Null[T any]() Optional[T] {
+	return Optional[T]{Set: true, Null: true}
+}
+
+// Get gives the value and tells if it's set and not null
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Set && !o.Null
+}
+
+// IsZero tells if the value is absent
+func (o Optional[T]) IsZero() bool {
+	return !o.Set
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set || o.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	o.Value, o.Set, o.Null = zero, true, bytes.Equal(data, []byte("null"))
+	if o.Null {
+		return nil
+	}
+	return json.Unmarshal(data, &o.Value)
+}
+
+func (o Optional[T]) state() (set, null bool) {
+	return o.Set, o.Null
+}
+
+func (o *Optional[T]) parse(raw string) error {
+	if err := setValue(reflect.ValueOf(&o.Value).Elem(), raw); err != nil {
+		return err
+	}
+	o.Set, o.Null = true, false
+	return nil
+}
+
+// optional is implemented by every Optional
+type optional interface {
+	state() (set, null bool)
+}
+
+// asOptional tells if val is an Optional, giving its state
+func asOptional(val reflect.Value) (optional, bool) {
+	if val.Kind() != reflect.Struct || !val.CanInterface() {
+		return nil, false
+	}
+	o, ok := val.Interface().(optional)
+	return o, ok
+}
@@ -0,0 +1,105 @@
+package validate
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+type PatchUser struct {
+	Name     Optional[string] `json:"name" validate:"required;min:2"`
+	Nickname Optional[string] `json:"nickname" validate:"nullable;omitempty"`
+	Age      Optional[int]    `json:"age" validate:"min:18"`
+	Email    *string          `json:"email" validate:"nullable;is:email"`
+	Country  *string          `json:"country" validate:"required;enum:CN,US"`
+}
+
+func TestOptional_json(t *testing.T) {
+	var u PatchUser
+	assert.Nil(t, json.Unmarshal([]byte(`{"name": "ab", "nickname": null}`), &u))
+	assert.Equal(t, Some("ab"), u.Name)
+	assert.Equal(t, Null[string](), u.Nickname)
+	assert.False(t, u.Age.Set)
+	_, ok := u.Nickname.Get()
+	assert.False(t, ok)
+
+	data, err := json.Marshal(struct {
+		A Optional[int] `json:"a"`
+		B Optional[int] `json:"b"`
+	}{A: Some(1)})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1,"b":null}`, string(data))
+}
+
+func TestOptional_validate(t *testing.T) {
+	country := "CN"
+	errs := Get().Validate(PatchUser{Country: &country})
+	assert.Equal(t, 1, len(errs), errs)
+	assert.Equal(t, CodeMissing, errs.For(".Name")[0].Code)
+
+	var u PatchUser
+	assert.Nil(t, json.Unmarshal([]byte(`{"name": "a", "nickname": "", "age": null}`), &u))
+	errs = Get().Validate(u)
+	assert.Equal(t, 3, len(errs), errs)
+	assert.Equal(t, CodeMin, errs.For(".Name")[0].Code)
+	assert.Equal(t, CodeNull, errs.For(".Age")[0].Code)
+	assert.Equal(t, CodeMissing, errs.For(".Country")[0].Code)
+
+	email, country := "x", "JP"
+	errs = Get().Validate(PatchUser{Name: Some("abc"), Age: Some(17), Email: &email, Country: &country})
+	assert.Equal(t, 3, len(errs), errs)
+	assert.True(t, errs.For(".Age").Has(CodeMin))
+	assert.True(t, errs.For(".Email").Has(CodeIs))
+	assert.True(t, errs.For(".Country").Has(CodeEnum))
+}
+
+func TestOptional_presentEmpty(t *testing.T) {
+	empty := ""
+	var v struct {
+		Required *string `validate:"required"`
+		Untagged *string
+		Min      *string          `validate:"min:1"`
+		Email    *string          `validate:"is:email"`
+		Omit     *string          `validate:"omitempty;is:email"`
+		Some     Optional[string] `validate:"required"`
+	}
+	v.Required, v.Untagged, v.Min, v.Email, v.Omit, v.Some = &empty, &empty, &empty, &empty, &empty, Some("")
+	errs := Get().Validate(v)
+	assert.Equal(t, 2, len(errs), errs)
+	assert.Equal(t, CodeMin, errs.For(".Min")[0].Code)
+	assert.Equal(t, CodeIs, errs.For(".Email")[0].Code)
+
+	v.Required, v.Untagged, v.Min, v.Email, v.Some = nil, nil, nil, nil, Optional[string]{}
+	errs = Get().Validate(v)
+	assert.Equal(t, 5, len(errs), errs)
+	assert.Equal(t, CodeMissing, errs.For(".Required")[0].Code)
+	assert.Equal(t, CodeRequired, errs.For(".Untagged")[0].Code)
+	assert.Equal(t, CodeMissing, errs.For(".Some")[0].Code)
+
+	s := JSONSchema(v, nil)
+	assert.Nil(t, s.Properties["Required"].MinLength)
+	assert.Equal(t, int64(1), *s.Properties["Min"].MinLength)
+}
+
+func TestOptional_rules(t *testing.T) {
+	data := map[string]Optional[int]{"a": {}, "b": Null[int]()}
+	errs := Get().Validate(data, Rules{".a": "required", ".b": "nullable"})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, CodeMissing, errs[0].Code)
+	assert.Nil(t, Get().Validate(data, Rules{".b": "nullable"}).Err())
+	assert.True(t, ValidateJSON([]byte(`{"a": null}`), Rules{"a": "nullable"}).Err() == nil)
+	assert.True(t, ValidateJSON([]byte(`{}`), Rules{"a": "required;omitempty"}).Has(CodeMissing))
+}
+
+func TestOptional_bind(t *testing.T) {
+	var q struct {
+		Page Optional[int] `query:"page" validate:"default:1"`
+		Size Optional[int] `query:"size" validate:"omitempty;max:100"`
+	}
+	assert.Nil(t, ValidateQuery(url.Values{"size": {"20"}}, &q).Err())
+	assert.Equal(t, Some(1), q.Page)
+	assert.Equal(t, Some(20), q.Size)
+	assert.True(t, ValidateQuery(url.Values{"size": {"200"}}, &q).Has(CodeMax))
+}
@@ -0,0 +1,246 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PathFormat is the notation of field paths in errors, Rules keys may be written in any of them
+type PathFormat int
+
+const (
+	DotPath     PathFormat = iota // .items.0.name
+	JSONPointer                   // /items/0/name, see RFC 6901
+	JSONPath                      // $.items[0].name
+	BracketPath                   // items[0].name
+)
+
+// SegmentKind tells what a path segment steps into
+type SegmentKind int
+
+const (
+	FieldSegment SegmentKind = iota // a struct field
+	IndexSegment                    // an element of a slice
+	KeySegment                      // an entry of a map
+)
+
+var segmentKinds = []string{"field", "index", "key"}
+
+func (k SegmentKind) String() string {
+	if int(k) < len(segmentKinds) {
+		return segmentKinds[k]
+	}
+	return "unknown"
+}
+
+func (k SegmentKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *SegmentKind) UnmarshalText(text []byte) error {
+	for i, name := range segmentKinds {
+		if name == string(text) {
+			*k = SegmentKind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown segment kind [%s]", text)
+}
+
+// Segment is a step of a Path, Name is the field name resolved by the name case, the index or the map key
+type Segment struct {
+	Kind     SegmentKind `json:"kind"`
+	Name     string      `json:"name"`
+	GoName   string      `json:"goName,omitempty"`
+	JSONName string      `json:"jsonName,omitempty"`
+	Index    int         `json:"index,omitempty"`
+}
+
+// MarshalJSON keeps the index of an index segment even if it's 0
+func (s Segment) MarshalJSON() ([]byte, error) {
+	type segment Segment
+	if s.Kind != IndexSegment {
+		return json.Marshal(segment(s))
+	}
+	return json.Marshal(struct {
+		segment
+		Index int `json:"index"`
+	}{segment(s), s.Index})
+}
+
+// Path locates a validated value from the root of the data
+type Path []Segment
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func (p Path) append(s Segment) Path {
+	ret := make(Path, len(p), len(p)+1)
+	copy(ret, p)
+	return append(ret, s)
+}
+
+func (p Path) field(f reflect.StructField, name string) Path {
+	jsonName := f.Name
+	if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == "-" {
+		jsonName = ""
+	} else if tag != "" {
+		jsonName = tag
+	}
+	return p.append(Segment{Kind: FieldSegment, Name: name, GoName: f.Name, JSONName: jsonName})
+}
+
+func (p Path) index(i int) Path {
+	return p.append(Segment{Kind: IndexSegment, Name: strconv.Itoa(i), Index: i})
+}
+
+func (p Path) key(key string) Path {
+	return p.append(Segment{Kind: KeySegment, Name: key})
+}
+
+func (p Path) String() string {
+	return p.Format(DotPath)
+}
+
+// Format writes the path in the notation
+func (p Path) Format(format PathFormat) string {
+	var b strings.Builder
+	switch format {
+	case JSONPointer:
+		for _, s := range p {
+			b.WriteString("/")
+			b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(s.Name))
+		}
+	case JSONPath, BracketPath:
+		if format == JSONPath {
+			b.WriteString("$")
+		}
+		for i, s := range p {
+			switch {
+			case s.Kind == IndexSegment:
+				b.WriteString("[" + s.Name + "]")
+			case identifier.MatchString(s.Name):
+				if i > 0 || format == JSONPath {
+					b.WriteString(".")
+				}
+				b.WriteString(s.Name)
+			default:
+				b.WriteString("['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s.Name) + "']")
+			}
+		}
+	default:
+		for _, s := range p {
+			b.WriteString("." + s.Name)
+		}
+	}
+	return b.String()
+}
+
+// match tells if p is matched by the pattern, a `*` segment in the pattern matches any segment
+func (p Path) match(pattern Path) bool {
+	if len(p) != len(pattern) {
+		return false
+	}
+	for i, s := range pattern {
+		if s.Name != "*" && s.Name != p[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// named tells if name names the segment, by its name, go name or json name, * names any
+func (s Segment) named(name string) bool {
+	return name == "*" || name == s.Name || s.GoName != "" && name == s.GoName || s.JSONName != "" && name == s.JSONName
+}
+
+func (p Path) wildcard() bool {
+	for _, s := range p {
+		if s.Name == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePath reads a path written as a JSON pointer (`/items/0/name`), JSONPath (`$.items[0].name`),
+// bracket notation (`items[0].name`) or the dotted notation (`.items.0.name`).
+// segments other than quoted map keys are taken as fields, or indexes when they are numbers.
+// empty segments, like the one of a trailing dot, are left out as they can't name anything but a quoted key
+func ParsePath(s string) Path {
+	var p Path
+	if strings.HasPrefix(s, "/") {
+		for _, name := range strings.Split(s[1:], "/") {
+			if name != "" {
+				p = p.append(guessSegment(strings.NewReplacer("~1", "/", "~0", "~").Replace(name)))
+			}
+		}
+		return p
+	}
+	s = strings.TrimPrefix(s, "$")
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			continue
+		case '[':
+			if len(s) > 1 && (s[1] == '\'' || s[1] == '"') {
+				name, rest, ok := unquoteKey(s[1:])
+				if ok && strings.HasPrefix(rest, "]") {
+					p = p.append(Segment{Kind: KeySegment, Name: name})
+					s = rest[1:]
+					continue
+				}
+			}
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				s += "]"
+				end = len(s) - 1
+			}
+			if end > 1 {
+				p = p.append(guessSegment(s[1:end]))
+			}
+			s = s[end+1:]
+			continue
+		}
+		end := strings.IndexAny(s, ".[")
+		if end < 0 {
+			end = len(s)
+		} else if end == 0 {
+			continue
+		}
+		p = p.append(guessSegment(s[:end]))
+		s = s[end:]
+	}
+	return p
+}
+
+// unquoteKey reads a quoted map key at the start of s, returning the rest after the closing quote
+func unquoteKey(s string) (string, string, bool) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case quote:
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", s, false
+}
+
+func guessSegment(name string) Segment {
+	if i, err := strconv.Atoi(name); err == nil {
+		return Segment{Kind: IndexSegment, Name: name, Index: i}
+	}
+	return Segment{Kind: FieldSegment, Name: name}
+}
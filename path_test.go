@@ -0,0 +1,142 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+type PathItem struct {
+	Name string
+}
+
+type PathCase struct {
+	Items []PathItem
+	Attrs map[string]string
+}
+
+func TestPath_format(t *testing.T) {
+	p := Path{}.key("items").index(0).key("name")
+	assert.Equal(t, ".items.0.name", p.Format(DotPath))
+	assert.Equal(t, "/items/0/name", p.Format(JSONPointer))
+	assert.Equal(t, "$.items[0].name", p.Format(JSONPath))
+	assert.Equal(t, "items[0].name", p.Format(BracketPath))
+
+	p = Path{}.key("attrs").key("a/b~c.d")
+	assert.Equal(t, "/attrs/a~1b~0c.d", p.Format(JSONPointer))
+	assert.Equal(t, "$.attrs['a/b~c.d']", p.Format(JSONPath))
+	assert.Equal(t, "attrs['a/b~c.d']", p.Format(BracketPath))
+	assert.Equal(t, "$['it\\'s']", Path{}.key("it's").Format(JSONPath))
+	assert.Equal(t, "", Path{}.Format(JSONPointer))
+	assert.Equal(t, "$", Path{}.Format(JSONPath))
+}
+
+func TestPath_parse(t *testing.T) {
+	for _, s := range []string{".items.0.name", "/items/0/name", "$.items[0].name", "items[0].name", "$['items'][0]['name']"} {
+		p := ParsePath(s)
+		assert.Equal(t, ".items.0.name", p.String(), s)
+		assert.Equal(t, IndexSegment, p[1].Kind, s)
+	}
+	assert.Equal(t, Path{{Kind: FieldSegment, Name: "attrs"}, {Kind: FieldSegment, Name: "a/b~c"}}, ParsePath("/attrs/a~1b~0c"))
+	assert.Equal(t, Path{{Kind: FieldSegment, Name: "attrs"}, {Kind: KeySegment, Name: "a.b"}}, ParsePath(`attrs["a.b"]`))
+	assert.Equal(t, Path{{Kind: FieldSegment, Name: "items"}, {Kind: FieldSegment, Name: "*"}}, ParsePath("$.items[*]"))
+	assert.Equal(t, 0, len(ParsePath("")))
+	ab := Path{{Kind: FieldSegment, Name: "a"}, {Kind: FieldSegment, Name: "b"}}
+	for _, s := range []string{"a.b.", "a..b", ".a.b.", "a[].b", "$..a.b", "/a//b/"} {
+		assert.Equal(t, ab, ParsePath(s), s)
+	}
+	for _, s := range []string{".", "..", "$", "$.", "/", "[]"} {
+		assert.Equal(t, 0, len(ParsePath(s)), s)
+	}
+	assert.Equal(t, Path{{Kind: KeySegment, Name: ""}}, ParsePath(`['']`))
+	errs := Get().Validate(map[string]string{"a": ""}, Rules{"a.": "omitempty"})
+	assert.Nil(t, errs)
+	assert.Equal(t, 1, len(ValidateErrors{{Fields: []string{".a"}}}.For("a.")))
+}
+
+func TestValidate_pathFormat(t *testing.T) {
+	r := PathCase{Items: []PathItem{{Name: "a"}, {}}, Attrs: map[string]string{"a.b": ""}}
+	for format, fields := range map[PathFormat][]string{
+		DotPath:     {".Items.1.Name", ".Attrs.a.b"},
+		JSONPointer: {"/Items/1/Name", "/Attrs/a.b"},
+		JSONPath:    {"$.Items[1].Name", "$.Attrs['a.b']"},
+		BracketPath: {"Items[1].Name", "Attrs['a.b']"},
+	} {
+		err := Get(FieldPaths(format)).Validate(r)
+		assert.True(t, len(err) == 2)
+		assert.Equal(t, []string{fields[0]}, err[0].Fields)
+		assert.Equal(t, []string{fields[1]}, err[1].Fields)
+	}
+
+	err := Get(FieldPaths(JSONPointer)).Validate(SignOption{})
+	assert.True(t, len(err) == 3)
+	for _, e := range err {
+		if len(e.Fields) == 3 {
+			assert.Equal(t, []string{"/Name", "/EmailAddr", "/PhoneNumber"}, e.Fields)
+		}
+	}
+}
+
+func TestValidate_pathRules(t *testing.T) {
+	r := PathCase{Items: []PathItem{{Name: "a"}, {Name: "bc"}}, Attrs: map[string]string{"a.b": "x", "c": "y"}}
+	for _, rules := range []Rules{
+		{".Items.*.Name": "min:2", ".Attrs.c": "enum:x"},
+		{"/Items/*/Name": "min:2", "/Attrs/c": "enum:x"},
+		{"$.Items[*].Name": "min:2", "$.Attrs.c": "enum:x"},
+		{"Items[*].Name": "min:2", "Attrs['c']": "enum:x"},
+	} {
+		err := Get(FieldPaths(JSONPointer)).Validate(r, rules)
+		assert.True(t, len(err) == 2, rules)
+		fields := map[string]bool{err[0].Fields[0]: true, err[1].Fields[0]: true}
+		assert.True(t, fields["/Items/0/Name"] && fields["/Attrs/c"], err)
+	}
+	err := Get().Validate(r, Rules{"$.Attrs['a.b']": "enum:y"})
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, []string{".Attrs.a.b"}, err[0].Fields)
+}
+
+type LineItem struct {
+	SKU string `json:"sku"`
+}
+
+type OrderCase struct {
+	LineItems []LineItem        `json:"line_items"`
+	Meta      map[string]string `json:"-" validate:"omitempty"`
+}
+
+func TestValidate_paths(t *testing.T) {
+	r := OrderCase{LineItems: []LineItem{{SKU: "a"}, {}}, Meta: map[string]string{"note": ""}}
+	err := Get(NameCase(KebabCase)).Validate(r)
+	assert.True(t, len(err) == 2)
+	assert.Equal(t, []string{".line-items.1.sku"}, err[0].Fields)
+	assert.Equal(t, Path{
+		{Kind: FieldSegment, Name: "line-items", GoName: "LineItems", JSONName: "line_items"},
+		{Kind: IndexSegment, Name: "1", Index: 1},
+		{Kind: FieldSegment, Name: "sku", GoName: "SKU", JSONName: "sku"},
+	}, err[0].Paths[0])
+	assert.Equal(t, Path{
+		{Kind: FieldSegment, Name: "meta", GoName: "Meta"},
+		{Kind: KeySegment, Name: "note"},
+	}, err[1].Paths[0])
+
+	data, e := json.Marshal(err[1].Paths[0])
+	assert.Nil(t, e)
+	assert.Equal(t, `[{"kind":"field","name":"meta","goName":"Meta"},{"kind":"key","name":"note"}]`, string(data))
+	var p Path
+	assert.Nil(t, json.Unmarshal(data, &p))
+	assert.Equal(t, err[1].Paths[0], p)
+
+	first := ParsePath("items[0].sku")
+	data, e = json.Marshal(first)
+	assert.Nil(t, e)
+	assert.Equal(t, `[{"kind":"field","name":"items"},{"kind":"index","name":"0","index":0},{"kind":"field","name":"sku"}]`, string(data))
+	p = nil
+	assert.Nil(t, json.Unmarshal(data, &p))
+	assert.Equal(t, first, p)
+
+	err = Get().Validate(SignOption{Name: "", Password: "x", RemoteIP: "x"})
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, 3, len(err[0].Paths))
+	assert.Equal(t, "email_addr", err[0].Paths[1][0].JSONName)
+}
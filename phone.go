@@ -0,0 +1,387 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type phoneRegion struct {
+	callingCode string
+	trunkPrefix string
+	minLength   int
+	maxLength   int
+}
+
+// calling code, trunk prefix and national significant number lengths per region,
+// see: https://www.itu.int/pub/T-SP-E.164D
+var phoneRegions = map[string]phoneRegion{
+	"AF": {"93", "0", 9, 9},
+	"AX": {"358", "0", 5, 12},
+	"AL": {"355", "0", 8, 9},
+	"DZ": {"213", "0", 8, 9},
+	"AS": {"1", "1", 10, 10},
+	"AD": {"376", "", 6, 9},
+	"AO": {"244", "", 9, 9},
+	"AI": {"1", "1", 10, 10},
+	"AQ": {"672", "", 6, 6},
+	"AG": {"1", "1", 10, 10},
+	"AR": {"54", "0", 10, 11},
+	"AM": {"374", "0", 8, 8},
+	"AW": {"297", "", 7, 7},
+	"AU": {"61", "0", 9, 9},
+	"AT": {"43", "0", 4, 13},
+	"AZ": {"994", "0", 9, 9},
+	"BS": {"1", "1", 10, 10},
+	"BH": {"973", "", 8, 8},
+	"BD": {"880", "0", 8, 10},
+	"BB": {"1", "1", 10, 10},
+	"BY": {"375", "8", 9, 10},
+	"BE": {"32", "0", 8, 9},
+	"BZ": {"501", "", 7, 7},
+	"BJ": {"229", "", 8, 10},
+	"BM": {"1", "1", 10, 10},
+	"BT": {"975", "", 7, 8},
+	"BO": {"591", "0", 8, 8},
+	"BQ": {"599", "", 7, 7},
+	"BA": {"387", "0", 8, 9},
+	"BW": {"267", "", 7, 8},
+	"BV": {"47", "", 8, 8},
+	"BR": {"55", "0", 10, 11},
+	"IO": {"246", "", 7, 7},
+	"BN": {"673", "", 7, 7},
+	"BG": {"359", "0", 7, 9},
+	"BF": {"226", "", 8, 8},
+	"BI": {"257", "", 8, 8},
+	"KH": {"855", "0", 8, 9},
+	"CM": {"237", "", 9, 9},
+	"CA": {"1", "1", 10, 10},
+	"CV": {"238", "", 7, 7},
+	"KY": {"1", "1", 10, 10},
+	"CF": {"236", "", 8, 8},
+	"TD": {"235", "", 8, 8},
+	"CL": {"56", "", 9, 9},
+	"CN": {"86", "0", 7, 11},
+	"CX": {"61", "0", 9, 9},
+	"CC": {"61", "0", 9, 9},
+	"CO": {"57", "", 8, 10},
+	"KM": {"269", "", 7, 7},
+	"CG": {"242", "", 9, 9},
+	"CD": {"243", "0", 9, 9},
+	"CK": {"682", "", 5, 5},
+	"CR": {"506", "", 8, 8},
+	"CI": {"225", "", 10, 10},
+	"HR": {"385", "0", 8, 9},
+	"CU": {"53", "0", 6, 8},
+	"CW": {"599", "", 7, 8},
+	"CY": {"357", "", 8, 8},
+	"CZ": {"420", "", 9, 9},
+	"DK": {"45", "", 8, 8},
+	"DJ": {"253", "", 8, 8},
+	"DM": {"1", "1", 10, 10},
+	"DO": {"1", "1", 10, 10},
+	"EC": {"593", "0", 8, 9},
+	"EG": {"20", "0", 8, 10},
+	"SV": {"503", "", 8, 8},
+	"GQ": {"240", "", 9, 9},
+	"ER": {"291", "0", 7, 7},
+	"EE": {"372", "", 7, 8},
+	"ET": {"251", "0", 9, 9},
+	"FK": {"500", "", 5, 5},
+	"FO": {"298", "", 6, 6},
+	"FJ": {"679", "", 7, 7},
+	"FI": {"358", "0", 5, 12},
+	"FR": {"33", "0", 9, 9},
+	"GF": {"594", "0", 9, 9},
+	"PF": {"689", "", 6, 8},
+	"TF": {"262", "0", 9, 9},
+	"GA": {"241", "0", 7, 8},
+	"GM": {"220", "", 7, 7},
+	"GE": {"995", "0", 9, 9},
+	"DE": {"49", "0", 5, 13},
+	"GH": {"233", "0", 9, 9},
+	"GI": {"350", "", 8, 8},
+	"GR": {"30", "", 10, 10},
+	"GL": {"299", "", 6, 6},
+	"GD": {"1", "1", 10, 10},
+	"GP": {"590", "0", 9, 9},
+	"GU": {"1", "1", 10, 10},
+	"GT": {"502", "", 8, 8},
+	"GG": {"44", "0", 10, 10},
+	"GN": {"224", "", 8, 9},
+	"GW": {"245", "", 7, 9},
+	"GY": {"592", "", 7, 7},
+	"HT": {"509", "", 8, 8},
+	"HM": {"672", "", 6, 6},
+	"VA": {"39", "", 6, 11},
+	"HN": {"504", "", 8, 8},
+	"HK": {"852", "", 8, 8},
+	"HU": {"36", "06", 8, 9},
+	"IS": {"354", "", 7, 9},
+	"IN": {"91", "0", 10, 10},
+	"ID": {"62", "0", 8, 12},
+	"IR": {"98", "0", 10, 10},
+	"IQ": {"964", "0", 8, 10},
+	"IE": {"353", "0", 7, 9},
+	"IM": {"44", "0", 10, 10},
+	"IL": {"972", "0", 8, 9},
+	"IT": {"39", "", 6, 11},
+	"JM": {"1", "1", 10, 10},
+	"JP": {"81", "0", 9, 10},
+	"JE": {"44", "0", 10, 10},
+	"JO": {"962", "0", 8, 9},
+	"KZ": {"7", "8", 10, 10},
+	"KE": {"254", "0", 9, 9},
+	"KI": {"686", "", 5, 8},
+	"KP": {"850", "0", 6, 10},
+	"KR": {"82", "0", 8, 10},
+	"KW": {"965", "", 8, 8},
+	"KG": {"996", "0", 9, 9},
+	"LA": {"856", "0", 8, 10},
+	"LV": {"371", "", 8, 8},
+	"LB": {"961", "0", 7, 8},
+	"LS": {"266", "", 8, 8},
+	"LR": {"231", "0", 7, 9},
+	"LY": {"218", "0", 9, 9},
+	"LI": {"423", "", 7, 9},
+	"LT": {"370", "8", 8, 8},
+	"LU": {"352", "", 4, 11},
+	"MO": {"853", "", 8, 8},
+	"MK": {"389", "0", 8, 8},
+	"MG": {"261", "0", 9, 9},
+	"MW": {"265", "0", 7, 9},
+	"MY": {"60", "0", 8, 10},
+	"MV": {"960", "", 7, 7},
+	"ML": {"223", "", 8, 8},
+	"MT": {"356", "", 8, 8},
+	"MH": {"692", "", 7, 7},
+	"MQ": {"596", "0", 9, 9},
+	"MR": {"222", "", 8, 8},
+	"MU": {"230", "", 7, 8},
+	"YT": {"262", "0", 9, 9},
+	"MX": {"52", "", 10, 10},
+	"FM": {"691", "", 7, 7},
+	"MD": {"373", "0", 8, 8},
+	"MC": {"377", "", 8, 9},
+	"MN": {"976", "0", 8, 8},
+	"ME": {"382", "0", 8, 9},
+	"MS": {"1", "1", 10, 10},
+	"MA": {"212", "0", 9, 9},
+	"MZ": {"258", "", 8, 9},
+	"MM": {"95", "0", 7, 10},
+	"NA": {"264", "0", 8, 9},
+	"NR": {"674", "", 7, 7},
+	"NP": {"977", "0", 8, 10},
+	"NL": {"31", "0", 9, 9},
+	"NC": {"687", "", 6, 6},
+	"NZ": {"64", "0", 8, 10},
+	"NI": {"505", "", 8, 8},
+	"NE": {"227", "", 8, 8},
+	"NG": {"234", "0", 8, 10},
+	"NU": {"683", "", 4, 4},
+	"NF": {"672", "", 6, 6},
+	"MP": {"1", "1", 10, 10},
+	"NO": {"47", "", 8, 8},
+	"OM": {"968", "", 8, 8},
+	"PK": {"92", "0", 9, 10},
+	"PW": {"680", "", 7, 7},
+	"PS": {"970", "0", 8, 9},
+	"PA": {"507", "", 7, 8},
+	"PG": {"675", "", 7, 8},
+	"PY": {"595", "0", 9, 9},
+	"PE": {"51", "0", 8, 9},
+	"PH": {"63", "0", 8, 10},
+	"PN": {"64", "", 9, 9},
+	"PL": {"48", "", 9, 9},
+	"PT": {"351", "", 9, 9},
+	"PR": {"1", "1", 10, 10},
+	"QA": {"974", "", 7, 8},
+	"RE": {"262", "0", 9, 9},
+	"RO": {"40", "0", 9, 9},
+	"RU": {"7", "8", 10, 10},
+	"RW": {"250", "0", 9, 9},
+	"BL": {"590", "0", 9, 9},
+	"SH": {"290", "", 4, 5},
+	"KN": {"1", "1", 10, 10},
+	"LC": {"1", "1", 10, 10},
+	"MF": {"590", "0", 9, 9},
+	"PM": {"508", "0", 6, 6},
+	"VC": {"1", "1", 10, 10},
+	"WS": {"685", "", 5, 7},
+	"SM": {"378", "", 6, 10},
+	"ST": {"239", "", 7, 7},
+	"SA": {"966", "0", 9, 9},
+	"SN": {"221", "", 9, 9},
+	"RS": {"381", "0", 8, 9},
+	"SC": {"248", "", 7, 7},
+	"SL": {"232", "0", 8, 8},
+	"SG": {"65", "", 8, 8},
+	"SX": {"1", "1", 10, 10},
+	"SK": {"421", "0", 9, 9},
+	"SI": {"386", "0", 8, 8},
+	"SB": {"677", "", 5, 7},
+	"SO": {"252", "0", 7, 9},
+	"ZA": {"27", "0", 9, 9},
+	"GS": {"500", "", 5, 5},
+	"SS": {"211", "0", 9, 9},
+	"ES": {"34", "", 9, 9},
+	"LK": {"94", "0", 9, 9},
+	"SD": {"249", "0", 9, 9},
+	"SR": {"597", "", 6, 7},
+	"SJ": {"47", "", 8, 8},
+	"SZ": {"268", "", 8, 8},
+	"SE": {"46", "0", 7, 9},
+	"CH": {"41", "0", 9, 9},
+	"SY": {"963", "0", 8, 9},
+	"TW": {"886", "0", 8, 9},
+	"TJ": {"992", "", 9, 9},
+	"TZ": {"255", "0", 9, 9},
+	"TH": {"66", "0", 8, 9},
+	"TL": {"670", "", 7, 8},
+	"TG": {"228", "", 8, 8},
+	"TK": {"690", "", 4, 4},
+	"TO": {"676", "", 5, 7},
+	"TT": {"1", "1", 10, 10},
+	"TN": {"216", "", 8, 8},
+	"TR": {"90", "0", 10, 10},
+	"TM": {"993", "8", 8, 8},
+	"TC": {"1", "1", 10, 10},
+	"TV": {"688", "", 5, 6},
+	"UG": {"256", "0", 9, 9},
+	"UA": {"380", "0", 9, 9},
+	"AE": {"971", "0", 8, 9},
+	"GB": {"44", "0", 9, 10},
+	"US": {"1", "1", 10, 10},
+	"UM": {"1", "1", 10, 10},
+	"UY": {"598", "0", 8, 8},
+	"UZ": {"998", "", 9, 9},
+	"VU": {"678", "", 5, 7},
+	"VE": {"58", "0", 10, 10},
+	"VN": {"84", "0", 9, 10},
+	"VG": {"1", "1", 10, 10},
+	"VI": {"1", "1", 10, 10},
+	"WF": {"681", "", 6, 6},
+	"EH": {"212", "0", 9, 9},
+	"YE": {"967", "0", 7, 9},
+	"ZM": {"260", "0", 9, 9},
+	"ZW": {"263", "0", 9, 10},
+	"XK": {"383", "0", 8, 9},
+}
+
+// regions sharing a calling code are resolved to the main one when the region can't be told from the number
+var mainPhoneRegions = map[string]string{
+	"1": "US", "7": "RU", "39": "IT", "44": "GB", "47": "NO", "61": "AU", "64": "NZ",
+	"212": "MA", "262": "RE", "358": "FI", "500": "FK", "590": "GP", "599": "CW", "672": "NF",
+}
+
+var callingCodes map[string][]string
+
+func init() {
+	callingCodes = make(map[string][]string)
+	for region, r := range phoneRegions {
+		callingCodes[r.callingCode] = append(callingCodes[r.callingCode], region)
+	}
+	for code, regions := range callingCodes {
+		sort.Strings(regions)
+		if main, ok := mainPhoneRegions[code]; ok {
+			sort.SliceStable(regions, func(i, j int) bool { return regions[i] == main })
+		}
+	}
+}
+
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+type PhoneRule struct {
+	Region string
+	E164   bool
+}
+
+type PhoneNumber struct {
+	Region      string
+	CallingCode string
+	National    string
+}
+
+func (p PhoneNumber) E164() string {
+	return "+" + p.CallingCode + p.National
+}
+
+func (r phoneRegion) fits(national string) bool {
+	return len(national) >= r.minLength && len(national) <= r.maxLength
+}
+
+// ParsePhone parses a phone number written either in international form (+44 20 7946 0958, 0044...)
+// or in the national form of region (020 7946 0958 with region GB)
+func ParsePhone(number, region string) (PhoneNumber, error) {
+	region = strings.ToUpper(region)
+	if region != "" && !iso3166_1_alpha2[region] {
+		return PhoneNumber{}, fmt.Errorf("%w: unknown region [%s]", ErrInvalidPhone, region)
+	}
+	n := strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "").Replace(number)
+	var international string
+	switch {
+	case strings.HasPrefix(n, "+"):
+		international = n[1:]
+	case strings.HasPrefix(n, "00"):
+		international = n[2:]
+	case strings.HasPrefix(n, "011") && phoneRegions[region].callingCode == "1":
+		international = n[3:]
+	}
+	if international != "" {
+		return parseInternationalPhone(international, region)
+	}
+	if region == "" {
+		return PhoneNumber{}, fmt.Errorf("%w: region is required for national number [%s]", ErrInvalidPhone, number)
+	}
+	if _, ok := digitsOf(n); !ok || n == "" {
+		return PhoneNumber{}, ErrInvalidPhone
+	}
+	r, ok := phoneRegions[region]
+	if !ok {
+		return PhoneNumber{}, fmt.Errorf("%w: no numbering plan for region [%s]", ErrInvalidPhone, region)
+	}
+	if national, ok := r.national(n); ok {
+		return PhoneNumber{Region: region, CallingCode: r.callingCode, National: national}, nil
+	}
+	return PhoneNumber{}, ErrInvalidPhone
+}
+
+func (r phoneRegion) national(n string) (string, bool) {
+	if r.trunkPrefix != "" && strings.HasPrefix(n, r.trunkPrefix) && r.fits(n[len(r.trunkPrefix):]) {
+		return n[len(r.trunkPrefix):], true
+	}
+	return n, r.fits(n)
+}
+
+func parseInternationalPhone(n, region string) (PhoneNumber, error) {
+	if _, ok := digitsOf(n); !ok || n == "" || n[0] == '0' {
+		return PhoneNumber{}, ErrInvalidPhone
+	}
+	for l := 1; l <= 3 && l < len(n); l++ {
+		regions, ok := callingCodes[n[:l]]
+		if !ok {
+			continue
+		}
+		if r, ok := phoneRegions[region]; ok && r.callingCode == n[:l] {
+			regions = append([]string{region}, regions...)
+		}
+		for _, candidate := range regions {
+			if national, ok := phoneRegions[candidate].national(n[l:]); ok {
+				return PhoneNumber{Region: candidate, CallingCode: n[:l], National: national}, nil
+			}
+		}
+		return PhoneNumber{}, ErrInvalidPhone
+	}
+	return PhoneNumber{}, fmt.Errorf("%w: unknown calling code", ErrInvalidPhone)
+}
+
+// IsPhone checks a phone number in international form
+func IsPhone(val any) bool {
+	s, ok := val.(string)
+	if !ok {
+		return false
+	}
+	_, err := ParsePhone(s, "")
+	return err == nil
+}
@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestParsePhone(t *testing.T) {
+	cases := []struct {
+		number string
+		region string
+		e164   string
+		found  string
+	}{
+		{"+44 20 7946 0958", "", "+442079460958", "GB"},
+		{"+44 (0)20 7946 0958", "", "+442079460958", "GB"},
+		{"020 7946 0958", "GB", "+442079460958", "GB"},
+		{"0044 20 7946 0958", "us", "+442079460958", "GB"},
+		{"(202) 555-0123", "US", "+12025550123", "US"},
+		{"1 202 555 0123", "US", "+12025550123", "US"},
+		{"011 44 20 7946 0958", "US", "+442079460958", "GB"},
+		{"+1 416 555 0123", "CA", "+14165550123", "CA"},
+		{"138 0013 8000", "CN", "+8613800138000", "CN"},
+		{"06 12345678", "NL", "+31612345678", "NL"},
+		{"+39 06 1234 5678", "", "+390612345678", "IT"},
+	}
+	for _, c := range cases {
+		phone, err := ParsePhone(c.number, c.region)
+		assert.Nil(t, err, "%s should be parsed", c.number)
+		assert.Equal(t, c.e164, phone.E164())
+		assert.Equal(t, c.found, phone.Region)
+	}
+	for _, bad := range []string{"-3.5", "+44 20 7946", "+999 1234567", "02079460958", "+44 20 7946 0958 12345"} {
+		_, err := ParsePhone(bad, "")
+		assert.NotNil(t, err, "%s should be invalid", bad)
+	}
+}
+
+type PhoneCase struct {
+	Mobile string `validate:"phone:GB,e164"`
+	Office string `validate:"phone:;omitempty"`
+}
+
+func TestValidate_phone(t *testing.T) {
+	r := PhoneCase{Mobile: "07700 900123", Office: "020 7946 0958"}
+	err := Get().Validate(&r)
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, "is not a valid phone number", err[0].Message)
+	assert.Equal(t, []string{".Office"}, err[0].Fields)
+	assert.Equal(t, "+447700900123", r.Mobile)
+
+	err = Get().Validate(map[string]string{"phone": "-3.5"}, Rules{".phone": "is:phone"})
+	assert.True(t, len(err) == 1)
+	err = Get().Validate(map[string]string{"phone": "+44 20 7946 0958"}, Rules{".phone": "is:phone"})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestIsPostcode(t *testing.T) {
+	cases := map[string][2][]string{
+		"US": {{"94043", "94043-1351"}, {"9404", "940431"}},
+		"GB": {{"SW1A 1AA", "EC1A1BB", "W1A 0AX", "m1 1ae"}, {"SW1A 1A", "1AA SW1"}},
+		"CA": {{"K1A 0B1", "H0H0H0"}, {"K1A 0BZ1", "D1A 0B1"}},
+		"DE": {{"10115"}, {"1011", "101155"}},
+		"FR": {{"75008"}, {"7500"}},
+		"NL": {{"1012 AB", "1012AB"}, {"1012"}},
+		"JP": {{"100-0001", "1000001"}, {"100-001"}},
+		"CN": {{"100000"}, {"10000"}},
+		"BR": {{"01310-100", "01310100"}, {"0131-100"}},
+		"IN": {{"110001"}, {"11000"}},
+		"AU": {{"2000"}, {"200"}},
+		"PL": {{"00-950"}, {"00950"}},
+		"PT": {{"1000-001"}, {"1000"}},
+		"HK": {nil, {"999077"}},
+	}
+	for country, c := range cases {
+		for _, v := range c[0] {
+			assert.True(t, IsPostcode(country, v), "%s should be a postcode of %s", v, country)
+		}
+		for _, v := range c[1] {
+			assert.False(t, IsPostcode(country, v), "%s should not be a postcode of %s", v, country)
+		}
+	}
+	assert.True(t, atoms["postcodeGB"]("SW1A 1AA"))
+}
+
+type AddressCase struct {
+	Country  string `json:"country"`
+	Postcode string `json:"postcode" validate:"postcode:field=country"`
+	Zip      string `validate:"postcode:US;omitempty"`
+}
+
+func TestValidate_postcode(t *testing.T) {
+	err := Get().Validate(AddressCase{Country: "GB", Postcode: "SW1A 1AA"})
+	assert.Nil(t, err)
+	err = Get().Validate(AddressCase{Country: "US", Postcode: "SW1A 1AA", Zip: "1234"})
+	assert.True(t, len(err) == 2)
+	assert.Equal(t, "is not a valid postal code", err[0].Message)
+	assert.Equal(t, []string{".Postcode"}, err[0].Fields)
+	assert.Equal(t, []string{".Zip"}, err[1].Fields)
+
+	err = Get().Validate(map[string]string{
+		"country":  "DE",
+		"postcode": "1011",
+	}, Rules{".postcode": "postcode:field=country"})
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, []string{".postcode"}, err[0].Fields)
+}
@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// InvalidParam is an entry of the `invalid-params` extension of a Problem
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+	Code   string `json:"code,omitempty"`
+	Params any    `json:"params,omitempty"`
+	Label  string `json:"label,omitempty"`
+}
+
+// Problem is an RFC 7807 problem detail for validation errors
+type Problem struct {
+	Type          string         `json:"type,omitempty"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params"`
+}
+
+// NewProblem describes the errors as a problem, the title is translated by the printer the options set up.
+// an error of several fields gives an invalid param for each
+func NewProblem(status int, errs ValidateErrors, opt ...Option) *Problem {
+	v := validator{}.Config(opt...)
+	p := &Problem{
+		Title:         v.printer.Sprintf("Your request parameters didn't validate."),
+		Status:        status,
+		InvalidParams: make([]InvalidParam, 0, len(errs)),
+	}
+	for _, err := range errs {
+		for _, field := range err.Fields {
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{
+				Name:   field,
+				Reason: err.Message,
+				Code:   err.Code,
+				Params: err.Params,
+				Label:  err.Label,
+			})
+		}
+	}
+	return p
+}
+
+// Write sends the problem as the response
+func (p *Problem) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", ProblemContentType)
+	if p.Status != 0 {
+		w.WriteHeader(p.Status)
+	}
+	return json.NewEncoder(w).Encode(p)
+}
+
+func (p *Problem) Error() string {
+	return p.Title
+}
+
+// Errors turns the invalid params back into validate errors, one for each param
+func (p *Problem) Errors() ValidateErrors {
+	if len(p.InvalidParams) == 0 {
+		return nil
+	}
+	errs := make(ValidateErrors, len(p.InvalidParams))
+	for i, param := range p.InvalidParams {
+		errs[i] = ValidateError{
+			Fields:  []string{param.Name},
+			Code:    param.Code,
+			Params:  param.Params,
+			Label:   param.Label,
+			Message: param.Reason,
+		}
+	}
+	return errs
+}
+
+// DecodeProblem reads a problem from a response body
+func DecodeProblem(r io.Reader) (*Problem, error) {
+	var p Problem
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tj/assert"
+	"golang.org/x/text/language"
+)
+
+func TestProblem(t *testing.T) {
+	errs := Get(FieldPaths(JSONPointer)).Validate(ErrorsCase{Items: []ErrorsItem{{SKU: "a", Qty: 1}}, Note: "x"})
+	assert.True(t, len(errs) == 1)
+
+	w := httptest.NewRecorder()
+	assert.Nil(t, NewProblem(422, errs).Write(w))
+	assert.Equal(t, 422, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"title": "Your request parameters didn't validate.",
+		"status": 422,
+		"invalid-params": [
+			{"name": "/Items/0/SKU", "reason": "has a minimum length of 3 characters", "code": "min", "params": 3}
+		]
+	}`, w.Body.String())
+
+	p, err := DecodeProblem(w.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, 422, p.Status)
+	decoded := p.Errors()
+	assert.Equal(t, []string{"/Items/0/SKU"}, decoded[0].Fields)
+	assert.Equal(t, CodeMin, decoded[0].Code)
+	assert.Equal(t, float64(3), decoded[0].Params)
+	assert.Equal(t, 1, len(decoded.For("/Items/0/SKU")))
+
+	zh := Get(Language(language.Chinese)).Validate(ErrorsCase{Note: "x"})
+	p = NewProblem(400, zh, Language(language.Chinese))
+	assert.Equal(t, "请求参数校验失败。", p.Title)
+	assert.Equal(t, "不允许为空", p.InvalidParams[0].Reason)
+	assert.Equal(t, ".Items", p.InvalidParams[0].Name)
+
+	p = NewProblem(400, ValidateErrors{{Fields: []string{".a", ".b"}, Code: CodeMust, Message: "x"}})
+	assert.Equal(t, 2, len(p.InvalidParams))
+	assert.Nil(t, NewProblem(400, nil).Errors())
+}
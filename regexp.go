@@ -202,10 +202,10 @@ func IsBase64RawURL(val any) bool {
 	return isMatchingRegexp(val, base64RawURLRegex)
 }
 func IsISBN10(val any) bool {
-	return isMatchingRegexp(val, iSBN10Regex)
+	return isMatchingRegexp(val, iSBN10Regex) && isbn10Checksum(val.(string))
 }
 func IsISBN13(val any) bool {
-	return isMatchingRegexp(val, iSBN13Regex)
+	return isMatchingRegexp(val, iSBN13Regex) && gtin(val.(string), 13)
 }
 func IsUUID3(val any) bool {
 	return isMatchingRegexp(val, uUID3Regex)
@@ -296,16 +296,16 @@ func IsFqdn(val any) bool {
 	return isMatchingRegexp(val, fqdnRegexRFC1123)
 }
 func IsBtcAddress(val any) bool {
-	return isMatchingRegexp(val, btcAddressRegex)
+	return isMatchingRegexp(val, btcAddressRegex) && base58Check(val.(string))
 }
 func IsBtcUpperAddress(val any) bool {
-	return isMatchingRegexp(val, btcUpperAddressRegexBech32)
+	return isMatchingRegexp(val, btcUpperAddressRegexBech32) && segwitChecksum(val.(string))
 }
 func IsBtcLowerAddress(val any) bool {
-	return isMatchingRegexp(val, btcLowerAddressRegexBech32)
+	return isMatchingRegexp(val, btcLowerAddressRegexBech32) && segwitChecksum(val.(string))
 }
 func IsEthAddress(val any) bool {
-	return isMatchingRegexp(val, ethAddressRegex)
+	return isMatchingRegexp(val, ethAddressRegex) && eip55Checksum(val.(string))
 }
 func IsURLEncoded(val any) bool {
 	return isMatchingRegexp(val, uRLEncodedRegex)
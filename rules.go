@@ -2,21 +2,32 @@ package validate
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/dev-mockingbird/logf"
 	"github.com/spf13/cast"
 	"github.com/thoas/go-funk"
+	"golang.org/x/text/message"
 )
 
 type ValidateError struct {
 	Fields  []string `json:"fields"`
+	Paths   []Path   `json:"paths,omitempty"`
+	Code    string   `json:"code,omitempty"`
+	Params  any      `json:"params,omitempty"`
+	Label   string   `json:"label,omitempty"`
 	Message string   `json:"message"`
+	err     error
 }
 
 func (v ValidateError) Error() string {
+	if v.Label != "" {
+		return fmt.Sprintf("%s %s", v.Label, v.Message)
+	}
 	return fmt.Sprintf("`%s` %s", strings.Join(v.Fields, ","), v.Message)
 }
 
@@ -39,61 +50,132 @@ type Rule struct {
 	Min       *int64
 	Max       *int64
 	Regexp    string
+	Phone     *PhoneRule
+	Postcode  string
+	Currency  string
+	Mods      []string
+	Default   string
+	Unit      LengthUnit
+	Message   string
+	Label     string
 	Callback  func(interface{}) error
 	Omitempty bool
+	Required  bool
+	Nullable  bool
+	Empty     bool
 	validator *validator
+	parent    reflect.Value
+	path      Path
+	present   bool
+	minFloat  *float64
+	maxFloat  *float64
 }
 
 type Rules map[string]any
 
 func (r Rule) Validate(val reflect.Value, prev string) (empty bool, errs ValidateErrors) {
+	r.path = r.pathOf(prev)
 	isNotEmpty := func(valueEmpty bool) bool {
 		empty = valueEmpty
 		if valueEmpty {
-			if !r.Omitempty {
-				errs = append(errs, ValidateError{
-					Fields:  []string{prev},
-					Message: r.validator.printer.Sprintf("not allow empty"),
-				})
+			// the value of a pointer or an Optional is present, the rest of the rule checks it unless omitempty
+			if r.present {
+				return !r.Omitempty
+			}
+			if !r.Omitempty || r.Required {
+				errs = append(errs, r.newError(prev, CodeRequired, val.Interface(), nil, r.validator.printer.Sprintf("not allow empty")))
 			}
 			return false
 		}
 		return true
 	}
+	if o, ok := asOptional(val); ok {
+		if set, _ := o.state(); !set && r.Default != "" && val.CanSet() {
+			if e := setValue(val, r.Default); e != nil {
+				r.validator.logger.Logf(logf.Warn, "set default value for `%s` failed: %s", prev, e.Error())
+			}
+		}
+		set, null := o.state()
+		if !set || null {
+			return true, r.absent(prev, null)
+		}
+		r.Default, r.Required, r.present = "", false, true
+		return r.Validate(val.Field(0), prev)
+	}
+	if r.Empty {
+		if !isEmpty(val) && !val.IsZero() {
+			errs = append(errs, r.newError(prev, CodeEmpty, val.Interface(), nil, r.validator.printer.Sprintf("should be empty")))
+		}
+		return true, errs
+	}
+	if r.Default != "" && val.CanSet() && val.IsZero() {
+		if e := setValue(val, r.Default); e != nil {
+			r.validator.logger.Logf(logf.Warn, "set default value for `%s` failed: %s", prev, e.Error())
+		}
+	}
+	if len(r.Mods) > 0 && val.Kind() == reflect.String {
+		transformed := reflect.ValueOf(r.transform(val.String())).Convert(val.Type())
+		if val.CanSet() {
+			val.Set(transformed)
+		} else {
+			val = transformed
+		}
+	}
 	if r.Callback != nil {
 		er := r.Callback(val.Interface())
 		if er != nil {
-			errs = append(errs, ValidateError{
-				Fields:  []string{prev},
-				Message: er.Error(),
-			})
+			e := r.newError(prev, CodeCallback, val.Interface(), nil, er.Error())
+			e.err = er
+			errs = append(errs, e)
 		}
 		return
 	}
 	switch val.Type().Kind() {
 	case reflect.Slice, reflect.Array:
 		if isNotEmpty(val.Len() == 0) {
-			errs = append(errs, r.validator.validateReflectValue(val, prev)...)
+			errs = append(errs, r.validator.validateReflectValue(val, r.path)...)
 		}
 	case reflect.Interface:
 		_ = isNotEmpty(val.IsNil())
 	case reflect.Map:
 		if isNotEmpty(len(val.MapKeys()) == 0) {
-			errs = append(errs, r.validator.validateReflectValue(val, prev)...)
+			errs = append(errs, r.validator.validateReflectValue(val, r.path)...)
 		}
 	case reflect.String:
 		sval := val.String()
 		if !isNotEmpty(sval == "") {
 			return
 		}
+		if r.Phone != nil {
+			phone, e := ParsePhone(sval, r.Phone.Region)
+			if e != nil {
+				errs = append(errs, r.newError(prev, CodePhone, sval, r.Phone.Region, r.validator.printer.Sprintf("is not a valid phone number")))
+				return
+			}
+			if r.Phone.E164 {
+				sval = phone.E164()
+				if val.CanSet() {
+					val.SetString(sval)
+				}
+			}
+		}
+		if r.Postcode != "" {
+			if country, ok := r.paramValue(r.Postcode); ok && !IsPostcode(country, sval) {
+				errs = append(errs, r.newError(prev, CodePostcode, sval, country, r.validator.printer.Sprintf("is not a valid postal code")))
+				return
+			}
+		}
+		if r.Currency != "" {
+			if country, ok := r.paramValue(r.Currency); ok && !IsCurrencyOf(country, sval) {
+				errs = append(errs, r.newError(prev, CodeCurrency, sval, country, r.validator.printer.Sprintf("is not a currency of [%s]", country)))
+				return
+			}
+		}
 		if len(r.IsA) > 0 {
 			for _, a := range r.IsA {
 				if v, ok := atoms[a]; ok {
 					if !v(sval) {
-						errs = append(errs, ValidateError{
-							Fields:  []string{prev},
-							Message: r.validator.printer.Sprintf("is not one of the [%s]", strings.Join(r.IsA, ",")),
-						})
+						errs = append(errs, r.newError(prev, CodeIs, sval, strings.Join(r.IsA, ","), r.validator.printer.Sprintf("is not one of the [%s]", strings.Join(r.IsA, ","))))
 					}
 					return
 				}
@@ -104,41 +186,34 @@ func (r Rule) Validate(val reflect.Value, prev string) (empty bool, errs Validat
 		if r.Regexp != "" {
 			if re, e := regexp.Compile(r.Regexp); e != nil {
 				r.validator.logger.Logf(logf.Warn, "compile regexp for `%s` failed: %s", prev, e.Error())
-				errs = append(errs, ValidateError{
-					Fields:  []string{prev},
-					Message: r.validator.printer.Sprintf("can't compile regexp: %s", e.Error()),
-				})
+				errs = append(errs, r.newError(prev, CodeRegexp, sval, r.Regexp, r.validator.printer.Sprintf("can't compile regexp: %s", e.Error())))
 			} else if !re.MatchString(sval) {
-				errs = append(errs, ValidateError{
-					Fields:  []string{prev},
-					Message: r.validator.printer.Sprintf("cound be malformed"),
-				})
+				errs = append(errs, r.newError(prev, CodeRegexp, sval, r.Regexp, r.validator.printer.Sprintf("cound be malformed")))
 				return
 			}
 			return
 		}
 		if len(r.Enum) > 0 && !funk.ContainsString(r.Enum, sval) {
-			errs = append(errs, ValidateError{
-				Fields:  []string{prev},
-				Message: r.validator.printer.Sprintf("should be one of [%s], current value is [%s]", strings.Join(r.Enum, ","), sval),
-			})
+			errs = append(errs, r.newError(prev, CodeEnum, sval, strings.Join(r.Enum, ","), r.validator.printer.Sprintf("should be one of [%s], current value is [%s]", strings.Join(r.Enum, ","), sval)))
+			return
+		} else if r.Min != nil && r.length(sval) < int(*r.Min) {
+			errs = append(errs, r.newError(prev, CodeMin, sval, *r.Min, r.minLength(*r.Min)))
 			return
-		} else if r.Min != nil && len(sval) < int(*r.Min) {
-			errs = append(errs, ValidateError{
-				Fields:  []string{prev},
-				Message: r.validator.printer.Sprintf("has a minimum length [%d]", *r.Min),
-			})
+		} else if r.Max != nil && r.length(sval) > int(*r.Max) {
+			errs = append(errs, r.newError(prev, CodeMax, sval, *r.Max, r.maxLength(*r.Max)))
 			return
-		} else if r.Max != nil && len(sval) > int(*r.Max) {
-			errs = append(errs, ValidateError{
-				Fields:  []string{prev},
-				Message: r.validator.printer.Sprintf("has a maximum length [%d]", *r.Max),
-			})
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var ival int64
+		if val.CanInt() {
+			ival = val.Int()
+		} else {
+			ival = int64(val.Uint())
+		}
+		if r.Required && !isNotEmpty(ival == 0) {
 			return
 		}
-	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		ival := val.Int()
 		if len(r.Enum) > 0 {
 			if !funk.ContainsInt64(func() []int64 {
 				ret := make([]int64, len(r.Enum))
@@ -147,43 +222,255 @@ func (r Rule) Validate(val reflect.Value, prev string) (empty bool, errs Validat
 				}
 				return ret
 			}(), ival) {
-				errs = append(errs, ValidateError{
-					Fields:  []string{prev},
-					Message: r.validator.printer.Sprintf("should be one of [%s], current value is [%d]", strings.Join(r.Enum, ","), ival),
-				})
+				errs = append(errs, r.newError(prev, CodeEnum, ival, strings.Join(r.Enum, ","), r.validator.printer.Sprintf("should be one of [%s], current value is [%d]", strings.Join(r.Enum, ","), ival)))
 				return
 			}
 		} else if r.Min != nil && ival < *r.Min {
-			errs = append(errs, ValidateError{
-				Fields:  []string{prev},
-				Message: r.validator.printer.Sprintf("should be greater than equal [%d], current value is [%d]", *r.Min, ival),
-			})
+			errs = append(errs, r.newError(prev, CodeMin, ival, *r.Min, r.validator.printer.Sprintf("should be greater than equal [%d], current value is [%d]", *r.Min, ival)))
 			return
 		} else if r.Max != nil && ival > *r.Max {
-			errs = append(errs, ValidateError{
-				Fields:  []string{prev},
-				Message: r.validator.printer.Sprintf("should be less than equal [%d], current value is [%d]", *r.Max, ival),
-			})
+			errs = append(errs, r.newError(prev, CodeMax, ival, *r.Max, r.validator.printer.Sprintf("should be less than equal [%d], current value is [%d]", *r.Max, ival)))
+			return
+		}
+	case reflect.Float32, reflect.Float64:
+		fval := val.Float()
+		if r.Required && !isNotEmpty(fval == 0) {
+			return
+		}
+		bits := val.Type().Bits()
+		min, max := floatBound(r.minFloat, r.Min, bits), floatBound(r.maxFloat, r.Max, bits)
+		if len(r.Enum) > 0 {
+			if !funk.ContainsFloat64(func() []float64 {
+				ret := make([]float64, len(r.Enum))
+				for i, e := range r.Enum {
+					ret[i], _ = strconv.ParseFloat(e, bits)
+				}
+				return ret
+			}(), fval) {
+				errs = append(errs, r.newError(prev, CodeEnum, fval, strings.Join(r.Enum, ","), r.validator.printer.Sprintf("should be one of [%s], current value is [%v]", strings.Join(r.Enum, ","), fval)))
+				return
+			}
+		} else if min != nil && fval < *min {
+			errs = append(errs, r.newError(prev, CodeMin, fval, *min, r.validator.printer.Sprintf("should be greater than equal [%v], current value is [%v]", *min, fval)))
+			return
+		} else if max != nil && fval > *max {
+			errs = append(errs, r.newError(prev, CodeMax, fval, *max, r.validator.printer.Sprintf("should be less than equal [%v], current value is [%v]", *max, fval)))
 			return
 		}
 	case reflect.Struct:
-		errs = append(errs, r.validator.validateReflectValue(val, prev)...)
+		errs = append(errs, r.validator.validateReflectValue(val, r.path)...)
 	case reflect.Ptr:
-		if isNotEmpty(val.IsNil()) {
-			errs = append(errs, r.validator.validateReflectValue(val, prev)...)
+		if !val.IsNil() {
+			r.Default, r.Required, r.present = "", false, true
+			return r.Validate(val.Elem(), prev)
 		}
+		if r.Required || r.Nullable {
+			return true, r.absent(prev, false)
+		}
+		_ = isNotEmpty(true)
 	}
 	return
 }
 
+// floatBound is the bound of a float of bits, f is the bound as the tag wrote it and i the one for integers and lengths
+func floatBound(f *float64, i *int64, bits int) *float64 {
+	var b float64
+	switch {
+	case f != nil:
+		b = *f
+	case i != nil:
+		b = float64(*i)
+	default:
+		return nil
+	}
+	if bits == 32 {
+		b = float64(float32(b))
+	}
+	return &b
+}
+
+// parseBound parses the bound of min or max, a fractional one is rounded inward for integers and lengths
+func parseBound(s string, min bool) (i int64, f float64, err error) {
+	if i, err = strconv.ParseInt(s, 10, 64); err == nil {
+		return i, float64(i), nil
+	}
+	if f, err = strconv.ParseFloat(s, 64); err != nil {
+		return 0, 0, err
+	}
+	if min {
+		return int64(math.Ceil(f)), f, nil
+	}
+	return int64(math.Floor(f)), f, nil
+}
+
+// absent reports an Optional or a pointer without value, `required` rejects it unless it's null,
+// a null is rejected unless the rule is `nullable`
+func (r Rule) absent(prev string, null bool) ValidateErrors {
+	if null && !r.Nullable {
+		return ValidateErrors{r.newError(prev, CodeNull, nil, nil, r.validator.printer.Sprintf("should not be null"))}
+	}
+	if !null && r.Required {
+		return ValidateErrors{r.newError(prev, CodeMissing, nil, nil, r.validator.printer.Sprintf("is missing"))}
+	}
+	return nil
+}
+
+// newError reports a failure of the validated value, a message set on the rule replaces msg.
+// it's looked up in the catalog of the printer, then {field}, {label}, {value} and {params} are filled in
+func (r Rule) newError(prev, code string, value, params any, msg string) ValidateError {
+	p := r.pathOf(prev)
+	field := p.Format(r.validator.pathFormat)
+	label := r.label(field)
+	if r.Message != "" {
+		name := label
+		if name == "" {
+			name = field
+		}
+		var p string
+		if params != nil {
+			p = fmt.Sprint(params)
+		}
+		msg = strings.NewReplacer(
+			"{field}", field,
+			"{label}", name,
+			"{value}", fmt.Sprint(value),
+			"{params}", p,
+		).Replace(r.validator.text(r.Message))
+	}
+	return ValidateError{
+		Fields:  []string{field},
+		Paths:   []Path{p},
+		Code:    code,
+		Params:  params,
+		Label:   label,
+		Message: msg,
+	}
+}
+
+// label names the field for humans, from the rule or else the labeler of the validator, translated by the printer
+func (r Rule) label(field string) string {
+	label := r.Label
+	if label == "" && r.validator.labeler != nil {
+		label = r.validator.labeler(field)
+	}
+	if label == "" {
+		return ""
+	}
+	return r.validator.text(label)
+}
+
+// text translates s by the printer, s is looked up as it is but a % in s is literal when it's not translated.
+// the translation is a format like any of the catalog, so it doubles a literal %
+func (v *validator) text(s string) string {
+	return v.printer.Sprintf(message.Key(s, strings.ReplaceAll(s, "%", "%%")))
+}
+
+// pathOf is the path of the validated value, prev is parsed when the rule is used outside of a validator walk
+func (r Rule) pathOf(prev string) Path {
+	if r.path != nil || prev == "" {
+		return r.path
+	}
+	return ParsePath(prev)
+}
+
+// paramValue resolves a rule parameter, `field=<name>` refers to a sibling field of the validated one
+func (r Rule) paramValue(param string) (string, bool) {
+	name := strings.TrimPrefix(param, "field=")
+	if name == param {
+		return param, true
+	}
+	v, ok := r.validator.sibling(r.parent, name)
+	if !ok {
+		r.validator.logger.Logf(logf.Warn, "can't find sibling field [%s]", name)
+		return "", false
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if o, ok := asOptional(v); ok {
+		if set, null := o.state(); !set || null {
+			return "", false
+		}
+		v = v.Field(0)
+	}
+	if v.Kind() != reflect.String || v.String() == "" {
+		return "", false
+	}
+	return v.String(), true
+}
+
 func ParseValidateTag(rawrule string, rule *Rule, logger logf.Logfer) {
-	rawrules := strings.Split(rawrule, ";")
-	for _, rawrule := range rawrules {
-		if rawrule == "omitempty" {
+	parseTag(rawrule, rule, logger, nil)
+}
+
+// ruleKeys are the keys of rule entries with a value, like `min:2`
+var ruleKeys = map[string]bool{
+	"must": true, "regexp": true, "enum": true, "min": true, "max": true, "phone": true, "label": true, "msg": true,
+	"unit": true, "default": true, "mod": true, "postcode": true, "currency": true, "is": true, "range": true,
+}
+
+// isRuleKey tells if key starts a rule entry, with a value or as a flag like `omitempty` or a transform
+func isRuleKey(key string, hasValue bool) bool {
+	if hasValue {
+		return ruleKeys[key]
+	}
+	switch key {
+	case "omitempty", "required", "nullable", "empty":
+		return true
+	}
+	_, ok := transforms[key]
+	return ok
+}
+
+// parseTag parses the entries of rawrule. an entry is scoped to validation groups by `@group,...` right after its key,
+// like `required@update` or `max@admin,import:1000`, so a value may hold any `@`.
+// the entries scoped to any of the groups are parsed last so they win, those scoped to other groups are left out
+func parseTag(rawrule string, rule *Rule, logger logf.Logfer, groups []string) {
+	var rawrules, scoped []string
+	for _, rawrule := range strings.Split(rawrule, ";") {
+		key, value, hasValue := strings.Cut(rawrule, ":")
+		key, scope, ok := strings.Cut(key, "@")
+		if !ok {
+			rawrules = append(rawrules, rawrule)
+			continue
+		}
+		if !isRuleKey(key, hasValue) {
+			logger.Logf(logf.Warn, "can't recognize scoped rule [%s], it's left out", rawrule)
+			continue
+		}
+		if hasValue {
+			key += ":" + value
+		}
+		for _, group := range strings.Split(scope, ",") {
+			if funk.ContainsString(groups, group) {
+				scoped = append(scoped, key)
+				break
+			}
+		}
+	}
+	for _, rawrule := range append(rawrules, scoped...) {
+		switch rawrule {
+		case "omitempty":
 			rule.Omitempty = true
 			continue
+		case "required":
+			rule.Required = true
+			continue
+		case "nullable":
+			rule.Nullable = true
+			continue
+		case "empty":
+			rule.Empty = true
+			continue
 		}
-		kv := strings.Split(rawrule, ":")
+		if _, ok := transforms[rawrule]; ok {
+			rule.Mods = append(rule.Mods, rawrule)
+			continue
+		}
+		kv := strings.SplitN(rawrule, ":", 2)
 		if len(kv) < 2 {
 			logger.Logf(logf.Warn, "can't recognize rule [%s]", rawrule)
 			continue
@@ -195,12 +482,43 @@ func ParseValidateTag(rawrule string, rule *Rule, logger logf.Logfer) {
 			rule.Regexp = kv[1]
 		case "enum":
 			rule.Enum = strings.Split(kv[1], ",")
-		case "min":
-			min := cast.ToInt64(kv[1])
-			rule.Min = &min
-		case "max":
-			max := cast.ToInt64(kv[1])
-			rule.Max = &max
+		case "min", "max":
+			i, f, err := parseBound(kv[1], kv[0] == "min")
+			if err != nil && strings.Contains(kv[1], "@") {
+				logger.Logf(logf.Warn, "can't parse rule [%s], a scope goes right after the key like `%s@group:...`, it's left out", rawrule, kv[0])
+				continue
+			} else if err != nil {
+				logger.Logf(logf.Warn, "can't parse rule [%s], it's left out", rawrule)
+				continue
+			}
+			if kv[0] == "min" {
+				rule.Min, rule.minFloat = &i, &f
+			} else {
+				rule.Max, rule.maxFloat = &i, &f
+			}
+		case "phone":
+			rule.Phone = &PhoneRule{}
+			for _, p := range strings.Split(kv[1], ",") {
+				if p == "e164" {
+					rule.Phone.E164 = true
+				} else {
+					rule.Phone.Region = strings.ToUpper(p)
+				}
+			}
+		case "label":
+			rule.Label = kv[1]
+		case "msg":
+			rule.Message = kv[1]
+		case "unit":
+			rule.Unit = LengthUnit(kv[1])
+		case "default":
+			rule.Default = kv[1]
+		case "mod":
+			rule.Mods = append(rule.Mods, strings.Split(kv[1], ",")...)
+		case "postcode":
+			rule.Postcode = kv[1]
+		case "currency":
+			rule.Currency = kv[1]
 		case "is":
 			if kv[1] == "" {
 				continue
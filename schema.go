@@ -0,0 +1,342 @@
+package validate
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema (draft 2020-12), Extensions are written along with the keywords, keyed by `x-` names
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	ContentMediaType     string             `json:"contentMediaType,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	MinLength            *int64             `json:"minLength,omitempty"`
+	MaxLength            *int64             `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int64             `json:"minItems,omitempty"`
+	MaxItems             *int64             `json:"maxItems,omitempty"`
+	MinProperties        *int64             `json:"minProperties,omitempty"`
+	MaxProperties        *int64             `json:"maxProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Not                  *Schema            `json:"not,omitempty"`
+	Extensions           map[string]any     `json:"-"`
+}
+
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+	data, err := json.Marshal(schema(s))
+	if err != nil || len(s.Extensions) == 0 {
+		return data, err
+	}
+	ext, err := json.Marshal(s.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 2 {
+		return ext, nil
+	}
+	return append(append(data[:len(data)-1], ','), ext[1:]...), nil
+}
+
+// formats maps atoms of the `is` rule to the format or content keywords describing them
+var formats = map[string]Schema{
+	"email":           {Format: "email"},
+	"uuid":            {Format: "uuid"},
+	"uuid3":           {Format: "uuid"},
+	"uuid4":           {Format: "uuid"},
+	"uuid5":           {Format: "uuid"},
+	"uuid3RFC4122":    {Format: "uuid"},
+	"uuid4RFC4122":    {Format: "uuid"},
+	"uuid5RFC4122":    {Format: "uuid"},
+	"uUIDRFC4122":     {Format: "uuid"},
+	"hostnameRFC1123": {Format: "hostname"},
+	"fqdn":            {Format: "hostname"},
+	"dataURI":         {Format: "uri"},
+	"base64":          {ContentEncoding: "base64"},
+	"json":            {ContentMediaType: "application/json"},
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	optionalType = reflect.TypeOf((*optional)(nil)).Elem()
+)
+
+// JSONSchema describes the JSON of data by its validate tags and the rules, with the options naming fields and activating groups.
+// properties are named by json names, and the rules are looked up by the paths validation gives, `*` standing for indexes and map keys.
+// min/max, enum, regexp, omitempty, required, nullable, must groups and the atoms of `is` with a format are mapped to keywords,
+// other rules are kept as extensions: x-is, x-phone, x-postcode, x-currency, x-mods, x-length-unit, x-message, x-callback and x-empty
+func JSONSchema(data any, rules Rules, opt ...Option) *Schema {
+	v := validator{}.Config(opt...).With(rules)
+	s := &Schema{}
+	if typ := reflect.TypeOf(data); typ != nil {
+		s = v.schema(typ, Path{}, Rule{validator: v}, make(map[reflect.Type]bool))
+	}
+	s.Schema = SchemaDialect
+	return s
+}
+
+func (v *validator) schema(typ reflect.Type, p Path, rule Rule, seen map[reflect.Type]bool) *Schema {
+	s := &Schema{Title: rule.label(p.Format(v.pathFormat)), Extensions: make(map[string]any)}
+	// a pointer or an Optional which is present satisfies required, its value is described by the rest of the rule
+	nullable := false
+	for {
+		if typ.Kind() == reflect.Ptr {
+			nullable = nullable || rule.Nullable || rule.Omitempty && !rule.Required
+			typ = typ.Elem()
+		} else if typ.Kind() == reflect.Struct && typ.Implements(optionalType) {
+			nullable = nullable || rule.Nullable
+			typ = typ.Field(0).Type
+		} else {
+			break
+		}
+		rule.Required, rule.Omitempty = false, true
+	}
+	switch {
+	case typ == timeType:
+		s.Type, s.Format = "string", "date-time"
+	case typ.Kind() == reflect.String:
+		s.Type = "string"
+		v.stringSchema(s, rule)
+	case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Uint64:
+		s.Type = "integer"
+		v.integerSchema(s, rule, typ.Kind() >= reflect.Uint)
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		s.Type = "number"
+		v.numberSchema(s, rule)
+	case typ.Kind() == reflect.Bool:
+		s.Type = "boolean"
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		s.Type, s.ContentEncoding = "string", "base64"
+		s.MinLength, s.MaxLength = sizes(rule)
+	case typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array:
+		s.Type = "array"
+		ip := p.append(Segment{Kind: IndexSegment, Name: "*"})
+		s.Items = v.schema(typ.Elem(), ip, v.getRule(ip, ""), seen)
+		s.MinItems, s.MaxItems = sizes(rule)
+	case typ.Kind() == reflect.Map:
+		s.Type = "object"
+		kp := p.key("*")
+		s.AdditionalProperties = v.schema(typ.Elem(), kp, v.getRule(kp, ""), seen)
+		s.MinProperties, s.MaxProperties = sizes(rule)
+	case typ.Kind() == reflect.Struct:
+		s.Type = "object"
+		if seen[typ] {
+			s.Extensions["x-recursive"] = typ.String()
+			break
+		}
+		seen[typ] = true
+		v.structSchema(s, typ, p, seen)
+		delete(seen, typ)
+	}
+	if rule.Empty && s.Const == nil && s.MaxLength == nil && s.MaxItems == nil && s.MaxProperties == nil {
+		s.Extensions["x-empty"] = true
+	}
+	if nullable && s.Type != nil {
+		s.Type = []string{s.Type.(string), "null"}
+	}
+	if rule.Default != "" {
+		s.Default = rule.Default
+		switch typ.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if d, err := time.ParseDuration(rule.Default); typ == durationType && err == nil {
+				s.Default = int64(d)
+			} else if typ != durationType {
+				s.Default = cast.ToInt64(rule.Default)
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s.Default = cast.ToUint64(rule.Default)
+		case reflect.Float32, reflect.Float64:
+			s.Default = cast.ToFloat64(rule.Default)
+		case reflect.Bool:
+			s.Default = cast.ToBool(rule.Default)
+		}
+	}
+	if rule.Message != "" {
+		s.Extensions["x-message"] = rule.Message
+	}
+	if rule.Callback != nil {
+		s.Extensions["x-callback"] = true
+	}
+	return s
+}
+
+// sizes bounds the length of an array, a map or bytes, which are empty when the rule says so and not empty unless omitempty
+func sizes(rule Rule) (min, max *int64) {
+	zero, one := int64(0), int64(1)
+	if rule.Empty {
+		return nil, &zero
+	}
+	if !rule.Omitempty || rule.Required {
+		return &one, nil
+	}
+	return nil, nil
+}
+
+// stringSchema describes a string as the rule checks it, an atom or a regexp leaves out enum and length
+func (v *validator) stringSchema(s *Schema, rule Rule) {
+	s.MinLength, s.MaxLength = sizes(rule)
+	if rule.Empty {
+		return
+	}
+	if len(rule.Mods) > 0 {
+		s.Extensions["x-mods"] = rule.Mods
+	}
+	if rule.Phone != nil {
+		s.Extensions["x-phone"] = map[string]any{"region": rule.Phone.Region, "e164": rule.Phone.E164}
+	}
+	if rule.Postcode != "" {
+		s.Extensions["x-postcode"] = rule.Postcode
+	}
+	if rule.Currency != "" {
+		s.Extensions["x-currency"] = rule.Currency
+	}
+	for _, a := range rule.IsA {
+		if _, ok := atoms[a]; !ok {
+			continue
+		}
+		if f, ok := formats[a]; ok {
+			s.Format, s.ContentEncoding, s.ContentMediaType = f.Format, f.ContentEncoding, f.ContentMediaType
+		} else {
+			s.Extensions["x-is"] = a
+		}
+		return
+	}
+	if rule.Regexp != "" {
+		s.Pattern = rule.Regexp
+		return
+	}
+	for _, e := range rule.Enum {
+		s.Enum = append(s.Enum, e)
+	}
+	if rule.Min != nil && (s.MinLength == nil || *rule.Min > *s.MinLength) {
+		s.MinLength = rule.Min
+	}
+	if rule.Max != nil {
+		s.MaxLength = rule.Max
+	}
+	if (rule.Min != nil || rule.Max != nil) && rule.Unit != "" && rule.Unit != Runes {
+		s.Extensions["x-length-unit"] = rule.Unit
+	} else if (rule.Min != nil || rule.Max != nil) && rule.Unit == "" && v.lengthUnit != Runes {
+		s.Extensions["x-length-unit"] = v.lengthUnit
+	}
+}
+
+// integerSchema describes an integer as the rule checks it, an enum leaves out min and max
+func (v *validator) integerSchema(s *Schema, rule Rule, unsigned bool) {
+	if rule.Empty {
+		s.Const = 0
+		return
+	}
+	if rule.Required {
+		s.Not = &Schema{Const: 0}
+	}
+	if len(rule.Enum) > 0 {
+		for _, e := range rule.Enum {
+			s.Enum = append(s.Enum, cast.ToInt64(e))
+		}
+		return
+	}
+	s.Minimum, s.Maximum = floatBound(nil, rule.Min, 64), floatBound(nil, rule.Max, 64)
+	if unsigned && s.Minimum == nil {
+		zero := 0.0
+		s.Minimum = &zero
+	}
+}
+
+// numberSchema is integerSchema for floats, enum values and bounds may have fractions
+func (v *validator) numberSchema(s *Schema, rule Rule) {
+	v.integerSchema(s, rule, false)
+	if s.Minimum != nil {
+		s.Minimum = floatBound(rule.minFloat, rule.Min, 64)
+	}
+	if s.Maximum != nil {
+		s.Maximum = floatBound(rule.maxFloat, rule.Max, 64)
+	}
+	if s.Enum != nil {
+		s.Enum = nil
+		for _, e := range rule.Enum {
+			s.Enum = append(s.Enum, cast.ToFloat64(e))
+		}
+	}
+}
+
+// structSchema describes the fields of a struct as properties, a must group needs any of its fields
+func (v *validator) structSchema(s *Schema, typ reflect.Type, p Path, seen map[reflect.Type]bool) {
+	s.Properties = make(map[string]*Schema)
+	must := make(map[string][]string)
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fp := p.field(f, v.fieldName(f))
+		name := fp[len(fp)-1].JSONName
+		if name == "" {
+			continue
+		}
+		rule := v.getRule(fp, v.rawRule(f))
+		s.Properties[name] = v.schema(f.Type, fp, rule, seen)
+		if required(f.Type, rule) {
+			s.Required = append(s.Required, name)
+		}
+		for _, k := range rule.Must {
+			must[k] = append(must[k], name)
+		}
+	}
+	groups := make([]string, 0, len(must))
+	for k := range must {
+		groups = append(groups, k)
+	}
+	sort.Strings(groups)
+	for _, k := range groups {
+		group := &Schema{}
+		for _, name := range must[k] {
+			group.AnyOf = append(group.AnyOf, &Schema{Required: []string{name}})
+		}
+		s.AllOf = append(s.AllOf, group)
+	}
+	if len(s.AllOf) == 1 {
+		s.AnyOf, s.AllOf = s.AllOf[0].AnyOf, nil
+	}
+	if len(must) > 0 {
+		s.Extensions["x-must"] = must
+	}
+}
+
+// required tells if a field must be in the JSON, the zero value of a plain value is rejected unless omitempty
+func required(typ reflect.Type, rule Rule) bool {
+	switch {
+	case rule.Empty:
+		return false
+	case rule.Required:
+		return true
+	case typ.Kind() == reflect.Struct && typ.Implements(optionalType):
+		return false
+	case typ.Kind() == reflect.Ptr:
+		return !rule.Omitempty && !rule.Nullable
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Interface:
+		return !rule.Omitempty
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/tj/assert"
+)
+
+type SchemaItem struct {
+	SKU string `json:"sku" validate:"regexp:^[A-Z]+$"`
+	Qty int    `json:"qty" validate:"min:1;max:99"`
+}
+
+type SchemaOrder struct {
+	ID        string            `json:"id" validate:"is:uuid;omitempty;empty@create"`
+	Email     string            `json:"email" validate:"is:email;must:contact;omitempty"`
+	Phone     string            `json:"phone" validate:"phone:GB;must:contact;omitempty"`
+	Name      string            `json:"name" validate:"min:2;max:10;label:Name"`
+	Status    int               `json:"status" validate:"enum:1,2"`
+	Items     []SchemaItem      `json:"items"`
+	Attrs     map[string]string `json:"attrs" validate:"omitempty"`
+	Note      *string           `json:"note" validate:"nullable;max:100"`
+	Nickname  Optional[string]  `json:"nickname" validate:"required;omitempty"`
+	Retries   int               `json:"retries" validate:"default:3"`
+	CreatedAt time.Time         `json:"created_at"`
+	Secret    string            `json:"-"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	s := JSONSchema(SchemaOrder{}, nil)
+	assert.Equal(t, SchemaDialect, s.Schema)
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, []string{"name", "items", "nickname"}, s.Required)
+	assert.Nil(t, s.Properties["Secret"])
+
+	assert.Equal(t, "uuid", s.Properties["id"].Format)
+	assert.Equal(t, "email", s.Properties["email"].Format)
+	assert.Equal(t, map[string]any{"region": "GB", "e164": false}, s.Properties["phone"].Extensions["x-phone"])
+	assert.Equal(t, int64(2), *s.Properties["name"].MinLength)
+	assert.Equal(t, int64(10), *s.Properties["name"].MaxLength)
+	assert.Equal(t, "Name", s.Properties["name"].Title)
+	assert.Equal(t, []any{int64(1), int64(2)}, s.Properties["status"].Enum)
+	assert.Nil(t, s.Properties["status"].Minimum)
+	assert.Equal(t, int64(1), *s.Properties["items"].MinItems)
+	assert.Nil(t, s.Properties["attrs"].MinProperties)
+	assert.Equal(t, []string{"string", "null"}, s.Properties["note"].Type)
+	assert.Equal(t, "string", s.Properties["nickname"].Type)
+	assert.Nil(t, s.Properties["nickname"].MinLength)
+	assert.Equal(t, int64(3), s.Properties["retries"].Default)
+	assert.Equal(t, "date-time", s.Properties["created_at"].Format)
+
+	item := s.Properties["items"].Items
+	assert.Equal(t, "^[A-Z]+$", item.Properties["sku"].Pattern)
+	assert.Equal(t, 1.0, *item.Properties["qty"].Minimum)
+	assert.Equal(t, 99.0, *item.Properties["qty"].Maximum)
+	assert.Equal(t, []*Schema{{Required: []string{"email"}}, {Required: []string{"phone"}}}, s.AnyOf)
+
+	s = JSONSchema(&SchemaOrder{}, Rules{".Attrs.*": "max:9"}, Groups("create"))
+	assert.Equal(t, int64(0), *s.Properties["id"].MaxLength)
+	assert.Equal(t, "", s.Properties["id"].Format)
+	assert.Equal(t, int64(9), *s.Properties["attrs"].AdditionalProperties.MaxLength)
+}
+
+func TestJSONSchema_json(t *testing.T) {
+	data, err := json.Marshal(JSONSchema(struct {
+		Code  string `json:"code" validate:"is:alpha;mod:upper"`
+		Count uint   `json:"count" validate:"required"`
+	}{}, nil))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{`+
+		`"code":{"type":"string","minLength":1,"x-is":"alpha","x-mods":["upper"]},`+
+		`"count":{"type":"integer","minimum":0,"not":{"const":0}}},"required":["code","count"]}`, string(data))
+
+	s := JSONSchema(struct {
+		Rate  float64 `json:"rate" validate:"min:1;max:5"`
+		Step  float32 `json:"step" validate:"enum:0.5,1"`
+		Price float32 `json:"price" validate:"min:0.5;max:9.99"`
+	}{}, nil)
+	assert.Equal(t, 5.0, *s.Properties["rate"].Maximum)
+	assert.Equal(t, 0.5, *s.Properties["price"].Minimum)
+	assert.Equal(t, 9.99, *s.Properties["price"].Maximum)
+	assert.Equal(t, []any{0.5, 1.0}, s.Properties["step"].Enum)
+}
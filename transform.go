@@ -0,0 +1,73 @@
+package validate
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/dev-mockingbird/logf"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	transforms map[string]func(string) string
+)
+
+func init() {
+	transforms = map[string]func(string) string{
+		"trim":         strings.TrimSpace,
+		"lower":        strings.ToLower,
+		"upper":        strings.ToUpper,
+		"title":        Title,
+		"collapse":     CollapseSpace,
+		"nfc":          norm.NFC.String,
+		"nfkc":         norm.NFKC.String,
+		"stripControl": StripControl,
+		"e164":         NormalizePhone,
+	}
+}
+
+// RegisterTransform makes a transform usable in `mod:` rules, or directly by its name in the tag
+func RegisterTransform(name string, transform func(string) string) {
+	transforms[name] = transform
+}
+
+func Title(s string) string {
+	// casers keep state, so they can't be shared between goroutines
+	return cases.Title(language.Und).String(s)
+}
+
+// CollapseSpace trims s and replaces every run of white spaces with a single space
+func CollapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func StripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// NormalizePhone rewrites an international phone number in E.164 form, other values are kept as is
+func NormalizePhone(s string) string {
+	phone, err := ParsePhone(s, "")
+	if err != nil {
+		return s
+	}
+	return phone.E164()
+}
+
+func (r Rule) transform(s string) string {
+	for _, name := range r.Mods {
+		if transform, ok := transforms[name]; ok {
+			s = transform(s)
+			continue
+		}
+		r.validator.logger.Logf(logf.Warn, "not found transform [%s]", name)
+	}
+	return s
+}
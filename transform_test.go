@@ -0,0 +1,42 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+type TransformCase struct {
+	Email   string `validate:"trim;lower;is:email"`
+	Name    string `validate:"mod:collapse,title"`
+	Phone   string `validate:"mod:e164;phone:"`
+	Comment string `validate:"mod:stripControl,nfc;omitempty"`
+	Code    string `validate:"mod:shout;omitempty"`
+}
+
+func TestValidate_transform(t *testing.T) {
+	RegisterTransform("shout", func(s string) string { return strings.ToUpper(s) + "!" })
+	r := TransformCase{
+		Email:   "  John.Doe@Example.COM ",
+		Name:    "  jane   van der  berg ",
+		Phone:   "+44 20 7946 0958",
+		Comment: "café\x00",
+		Code:    "hi",
+	}
+	err := Get().Validate(&r)
+	assert.Nil(t, err)
+	assert.Equal(t, "john.doe@example.com", r.Email)
+	assert.Equal(t, "Jane Van Der Berg", r.Name)
+	assert.Equal(t, "+442079460958", r.Phone)
+	assert.Equal(t, "café", r.Comment)
+	assert.Equal(t, "HI!", r.Code)
+
+	// values which can't be set are still validated after the transforms
+	r = TransformCase{Email: "  ", Name: "x", Phone: "+44 20 7946 0958"}
+	err = Get().Validate(r)
+	assert.True(t, len(err) == 1)
+	assert.Equal(t, []string{".Email"}, err[0].Fields)
+	assert.Equal(t, "not allow empty", err[0].Message)
+	assert.Equal(t, "  ", r.Email)
+}
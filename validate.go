@@ -1,16 +1,20 @@
+//go:generate gotext -srclang=en -dir=catalog/locales update -lang=en,zh,ja,es,de,fr
+
 package validate
 
 import (
-	"fmt"
+	"errors"
+	"net/textproto"
 	"reflect"
 	"strings"
 
 	"github.com/dev-mockingbird/logf"
-	_ "github.com/dev-mockingbird/validate/catalog"
+	translations "github.com/dev-mockingbird/validate/catalog"
 	"github.com/ettle/strcase"
 	"github.com/spf13/cast"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
 )
 
 const (
@@ -25,37 +29,102 @@ type Validator interface {
 	Validate(data any, rules ...Rules) ValidateErrors
 }
 
+// checker is a Validator telling the error itself, like the adapter of a Validate func keeping errors of its own
+type checker interface {
+	Check(data any, rules ...Rules) error
+}
+
+// Check validates data by v, the error is nil when data is valid so it's safe to return as an error
+func Check(v Validator, data any, rules ...Rules) error {
+	if c, ok := v.(checker); ok {
+		return c.Check(data, rules...)
+	}
+	return v.Validate(data, rules...).Err()
+}
+
 type Validate func(data any, rules ...Rules) error
 
 func (v Validate) Validate(data any, rules ...Rules) error {
 	return v(data, rules...)
 }
 
+// Validator adapts the func to a Validator, an error other than validate errors becomes a ValidateError wrapping it
+func (v Validate) Validator() Validator {
+	return funcValidator(v)
+}
+
+// Func adapts a Validator to the Validate func type
+func Func(v Validator) Validate {
+	return func(data any, rules ...Rules) error {
+		return Check(v, data, rules...)
+	}
+}
+
+type funcValidator Validate
+
+func (v funcValidator) Validate(data any, rules ...Rules) ValidateErrors {
+	err := v(data, rules...)
+	if err == nil {
+		return nil
+	}
+	var errs ValidateErrors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	var e ValidateError
+	if errors.As(err, &e) {
+		return ValidateErrors{e}
+	}
+	return ValidateErrors{{Message: err.Error(), err: err}}
+}
+
+func (v funcValidator) Check(data any, rules ...Rules) error {
+	err := v(data, rules...)
+	var errs ValidateErrors
+	if errors.As(err, &errs) && len(errs) == 0 {
+		return nil
+	}
+	return err
+}
+
 func (v *validator) validate(data any, prev string) ValidateErrors {
 	val := reflect.ValueOf(data)
-	return v.validateReflectValue(val, prev)
+	return v.validateReflectValue(val, ParsePath(prev))
 }
 
-func (v *validator) concatName(prev, n string) string {
-	name := n
+func (v *validator) caseName(n string) string {
 	switch v.nameCase {
 	case CamelCase:
-		name = strcase.ToCamel(n)
+		return strcase.ToCamel(n)
 	case SnakeCase:
-		name = strcase.ToSnake(n)
+		return strcase.ToSnake(n)
 	case PascalCase:
-		name = strcase.ToPascal(n)
+		return strcase.ToPascal(n)
 	case KebabCase:
-		name = strcase.ToKebab(n)
+		return strcase.ToKebab(n)
+	}
+	return n
+}
+
+// fieldName names the field in paths, by the name tag of the validator, canonical for headers, or else its go name in the name case
+func (v *validator) fieldName(f reflect.StructField) string {
+	if v.nameTag != "" {
+		if name := strings.Split(f.Tag.Get(v.nameTag), ",")[0]; name != "" && name != "-" {
+			if v.nameTag == "header" {
+				return textproto.CanonicalMIMEHeaderKey(name)
+			}
+			return name
+		}
 	}
-	return fmt.Sprintf("%s.%s", prev, name)
+	return v.caseName(f.Name)
 }
 
-func (validator *validator) getRule(name, rawrule string) (rule Rule) {
+func (validator *validator) getRule(p Path, rawrule string) (rule Rule) {
+	name := p.String()
 	ruleOf := func(r any) Rule {
 		var ret Rule
 		if raw, ok := r.(string); ok {
-			ParseValidateTag(raw, &ret, validator.logger)
+			parseTag(raw, &ret, validator.logger, validator.groups)
 			return ret
 		} else if ret, ok = r.(Rule); ok {
 			return ret
@@ -65,47 +134,106 @@ func (validator *validator) getRule(name, rawrule string) (rule Rule) {
 		validator.logger.Logf(logf.Error, "can't find rule for [%s] with %#v", name, validator.rules)
 		return ret
 	}
-	var ok bool
-	var r any
-	r, ok = validator.rules[name]
 	defer func() {
 		rule.validator = validator
+		rule.path = p
 		if rawrule != "" {
-			ParseValidateTag(rawrule, &rule, validator.logger)
+			parseTag(rawrule, &rule, validator.logger, validator.groups)
 		}
 	}()
-	if ok {
+	if r, ok := validator.rules[name]; ok {
 		rule = ruleOf(r)
 		return
 	}
+	var wildcard any
 	for n, r := range validator.rules {
-		ns := strings.Split(n, ".")
-		names := strings.Split(name, ".")
-		if len(ns) != len(names) {
-			break
+		pattern := ParsePath(n)
+		if !p.match(pattern) {
+			continue
 		}
-		var notfound bool
-		for i := 0; i < len(ns); i++ {
-			if ns[i] == "*" || ns[i] == names[i] {
-				continue
-			}
-			notfound = true
-			break
-		}
-		if !notfound {
+		if !pattern.wildcard() {
 			rule = ruleOf(r)
-			break
+			return
 		}
+		wildcard = r
+	}
+	if wildcard != nil {
+		rule = ruleOf(wildcard)
 	}
 	return rule
 }
 
-func (validator *validator) validateReflectValue(val reflect.Value, prev string) (errs ValidateErrors) {
-	for val.Type().Kind() == reflect.Ptr {
+// sibling finds the field called name next to the validated one, by its go name, json name or resolved name
+func (validator *validator) sibling(parent reflect.Value, name string) (reflect.Value, bool) {
+	for parent.IsValid() && (parent.Kind() == reflect.Ptr || parent.Kind() == reflect.Interface) {
+		parent = parent.Elem()
+	}
+	if !parent.IsValid() {
+		return reflect.Value{}, false
+	}
+	switch parent.Kind() {
+	case reflect.Struct:
+		if f, ok := validator.siblingField(parent.Type(), name); ok {
+			return parent.FieldByIndex(f.Index), true
+		}
+	case reflect.Map:
+		if parent.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		v := parent.MapIndex(reflect.ValueOf(name).Convert(parent.Type().Key()))
+		return v, v.IsValid()
+	}
+	return reflect.Value{}, false
+}
+
+// siblingField finds the struct field called name, by its go name, json name or resolved name
+func (validator *validator) siblingField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.Name == name || jsonName == name || validator.fieldName(f) == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// rawRule is the rule in the tags of the field, the validate tag or else the omitempty of the json tag
+func (validator *validator) rawRule(f reflect.StructField) string {
+	if tag := f.Tag.Get("validate"); tag != "" {
+		return tag
+	} else if tag := f.Tag.Get("json"); !validator.omitJSONTag && tag != "" {
+		ts := strings.Split(tag, ",")
+		rawrule := "name:" + ts[0]
+		if len(ts) > 1 && ts[1] == "omitempty" {
+			rawrule += ";omitempty"
+		}
+		return rawrule
+	}
+	return ""
+}
+
+func (validator *validator) validateReflectValue(val reflect.Value, p Path) (errs ValidateErrors) {
+	for val.IsValid() && val.Type().Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
-	must := make(map[string][]string)
+	if !val.IsValid() {
+		return
+	}
+	must := make(map[string][]Path)
 	emptyes := make(map[string]bool)
+	touched := make(map[string]bool)
+	// skip leaves a value out of the mask to its must groups, walking into it for the fields in the mask
+	skip := func(v reflect.Value, p Path, rule Rule) {
+		errs = append(errs, validator.descend(v, p)...)
+		for _, k := range rule.Must {
+			must[k] = append(must[k], p)
+		}
+		emptyes[p.String()] = isEmpty(v)
+	}
 	switch val.Type().Kind() {
 	case reflect.Struct:
 		for i := 0; i < val.NumField(); i++ {
@@ -113,61 +241,85 @@ func (validator *validator) validateReflectValue(val reflect.Value, prev string)
 			if !f.IsExported() {
 				continue
 			}
-			fn := validator.concatName(prev, f.Name)
-			var rawrule string
-			if tag := f.Tag.Get("validate"); tag != "" {
-				rawrule = tag
-			} else if tag := f.Tag.Get("json"); !validator.omitJSONTag && tag != "" {
-				ts := strings.Split(tag, ",")
-				rawrule = "name:" + ts[0]
-				if len(ts) > 1 && ts[1] == "omitempty" {
-					rawrule += ";omitempty"
-				}
+			fp := p.field(f, validator.fieldName(f))
+			rule := validator.getRule(fp, validator.rawRule(f))
+			rule.parent = val
+			if !validator.covers(fp, rule, val) {
+				skip(val.Field(i), fp, rule)
+				continue
+			}
+			for _, k := range rule.Must {
+				touched[k] = true
 			}
-			rule := validator.getRule(fn, rawrule)
-			empty, err := rule.Validate(val.Field(i), fn)
+			empty, err := rule.Validate(val.Field(i), fp.String())
 			if err != nil {
 				errs = append(errs, err...)
 				continue
 			}
 			for _, k := range rule.Must {
-				must[k] = append(must[k], fn)
+				must[k] = append(must[k], fp)
 			}
-			emptyes[fn] = empty
+			emptyes[fp.String()] = empty
 		}
 	case reflect.Slice:
 		for i := 0; i < val.Len(); i++ {
-			k := fmt.Sprintf("%s.%d", prev, i)
+			ip := p.index(i)
 			v := val.Index(i)
-			empty, err := validator.getRule(k, "").Validate(v, k)
+			rule := validator.getRule(ip, "")
+			if !validator.covers(ip, rule, val) {
+				skip(v, ip, rule)
+				continue
+			}
+			empty, err := rule.Validate(v, ip.String())
 			if err != nil {
 				errs = append(errs, err...)
 				continue
 			}
-			emptyes[k] = empty
+			emptyes[ip.String()] = empty
 		}
 	case reflect.Map:
 		for _, key := range val.MapKeys() {
-			k := fmt.Sprintf("%s.%s", prev, cast.ToString(key.Interface()))
+			kp := p.key(cast.ToString(key.Interface()))
 			v := val.MapIndex(key)
-			empty, err := validator.getRule(k, "").Validate(v, k)
+			rule := validator.getRule(kp, "")
+			rule.parent = val
+			if !validator.covers(kp, rule, val) {
+				skip(v, kp, rule)
+				continue
+			}
+			empty, err := rule.Validate(v, kp.String())
 			if err != nil {
 				errs = append(errs, err...)
 				continue
 			}
-			emptyes[k] = empty
+			emptyes[kp.String()] = empty
 		}
 	}
-	for _, fields := range must {
+	for k := range must {
+		if !touched[k] {
+			delete(must, k)
+		}
+	}
+	errs = append(errs, validator.mustErrors(must, emptyes)...)
+	return
+}
+
+// mustErrors reports the must groups of which every field is empty
+func (validator *validator) mustErrors(must map[string][]Path, emptyes map[string]bool) (errs ValidateErrors) {
+	for _, paths := range must {
 		found := false
-		for _, field := range fields {
-			if !emptyes[field] {
+		fields := make([]string, len(paths))
+		for i, field := range paths {
+			if !emptyes[field.String()] {
 				found = true
 			}
+			fields[i] = field.Format(validator.pathFormat)
 		}
 		if !found {
 			errs = append(errs, ValidateError{
 				Fields:  fields,
+				Paths:   paths,
+				Code:    CodeMust,
 				Message: validator.printer.Sprintf("at least one of the fields should be valued"),
 			})
 		}
@@ -178,9 +330,19 @@ func (validator *validator) validateReflectValue(val reflect.Value, prev string)
 type validator struct {
 	logger      logf.Logfer
 	printer     *message.Printer
+	catalog     catalog.Catalog
+	language    language.Tag
+	accept      []language.Tag
 	rules       Rules
 	nameCase    int
 	omitJSONTag bool
+	nameTag     string
+	lengthUnit  LengthUnit
+	pathFormat  PathFormat
+	labeler     func(field string) string
+	mask        []Path
+	masked      bool
+	groups      []string
 }
 
 type Option func(*validator)
@@ -203,6 +365,42 @@ func NameCase(namecase int) Option {
 	}
 }
 
+// Length sets the unit string lengths are measured in, runes by default
+func Length(unit LengthUnit) Option {
+	return func(opts *validator) {
+		opts.lengthUnit = unit
+	}
+}
+
+// Labeler names fields in error messages when their rule has no label, an empty label keeps the field path
+func Labeler(labeler func(field string) string) Option {
+	return func(opts *validator) {
+		opts.labeler = labeler
+	}
+}
+
+// FieldPaths sets the notation of field paths in errors, DotPath by default
+func FieldPaths(format PathFormat) Option {
+	return func(opts *validator) {
+		opts.pathFormat = format
+	}
+}
+
+// Groups activates validation groups, rule entries scoped like `required@update` or `max@admin,import:1000`
+// apply only when a group of theirs is active, and win over the entries without scope
+func Groups(groups ...string) Option {
+	return func(opts *validator) {
+		opts.groups = append(opts.groups, groups...)
+	}
+}
+
+// NameTag names fields in paths by a tag like json, form, query or header, fields without it keep their go name in the name case
+func NameTag(tag string) Option {
+	return func(opts *validator) {
+		opts.nameTag = tag
+	}
+}
+
 func OmitJSONTag() Option {
 	return func(opts *validator) {
 		opts.omitJSONTag = true
@@ -235,12 +433,39 @@ func (v validator) Config(opt ...Option) *validator {
 	if ret.nameCase == 0 {
 		ret.nameCase = OriginCase
 	}
+	if ret.lengthUnit == "" {
+		ret.lengthUnit = Runes
+	}
+	if ret.language == language.Und {
+		ret.language = language.English
+	}
+	if ret.catalog == nil {
+		ret.catalog = translations.Default
+	}
+	if len(ret.accept) > 0 {
+		ret.language = Negotiate(ret.catalog, ret.accept...)
+	}
 	if ret.printer == nil {
-		ret.printer = message.NewPrinter(language.English)
+		ret.printer = message.NewPrinter(ret.language, message.Catalog(ret.catalog))
 	}
 	return &ret
 }
 
+// Catalog sets the catalog messages are translated with, the bundled one by default
+func Catalog(cat catalog.Catalog) Option {
+	return func(v *validator) {
+		v.catalog = cat
+	}
+}
+
+// Language sets the language of messages, they are printed with the catalog of the validator
+func Language(tag language.Tag) Option {
+	return func(v *validator) {
+		v.language = tag
+	}
+}
+
+// Printer prints messages with printer instead, which takes precedence over Catalog and Language
 func Printer(printer *message.Printer) Option {
 	return func(v *validator) {
 		v.printer = printer
@@ -254,6 +479,10 @@ func (v *validator) Validate(data any, rules ...Rules) ValidateErrors {
 	return v.validate(data, "")
 }
 
+func (v *validator) Check(data any, rules ...Rules) error {
+	return v.Validate(data, rules...).Err()
+}
+
 func Get(opt ...Option) Validator {
 	validator := validator{}
 	return validator.Config(opt...)
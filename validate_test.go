@@ -333,6 +333,29 @@ func TestValidate_rules(t *testing.T) {
 	assert.Nil(t, err, "err should be nil")
 }
 
+type PriceCase struct {
+	Price  float64 `validate:"min:0.5;max:9.99"`
+	Ratio  float32 `validate:"min:0.1;max:0.3"`
+	Weight float32 `validate:"enum:0.1,0.2"`
+}
+
+func TestValidate_float(t *testing.T) {
+	assert.Nil(t, Get().Validate(PriceCase{Price: 0.5, Ratio: 0.1, Weight: 0.1}))
+	assert.Nil(t, Get().Validate(PriceCase{Price: 9.99, Ratio: 0.3, Weight: 0.2}))
+	errs := Get().Validate(PriceCase{Price: 0.1, Ratio: 0.31, Weight: 0.3})
+	assert.Equal(t, 3, len(errs), errs)
+	assert.True(t, errs.For(".Price").Has(CodeMin))
+	assert.Equal(t, "should be greater than equal [0.5], current value is [0.1]", errs.For(".Price")[0].Message)
+	assert.True(t, errs.For(".Ratio").Has(CodeMax))
+	assert.True(t, errs.For(".Weight").Has(CodeEnum))
+	errs = Get().Validate(PriceCase{Price: 10, Ratio: 0.09, Weight: 0.1})
+	assert.True(t, errs.For(".Price").Has(CodeMax))
+	assert.True(t, errs.For(".Ratio").Has(CodeMin))
+	assert.Nil(t, Get().Validate(map[string]int{"a": 1}, Rules{".a": "min:0.5"}))
+	assert.True(t, Get().Validate(map[string]int{"a": 0}, Rules{".a": "required;min:0.5"}).Has(CodeRequired))
+	assert.True(t, Get().Validate(map[string]int{"a": 10}, Rules{".a": "max:9.99"}).Has(CodeMax))
+}
+
 func TestValidate_unexported(t *testing.T) {
 	validator := GetValidator()
 	r := map[string]UnexportedCase{